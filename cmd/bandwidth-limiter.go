@@ -0,0 +1,134 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// bandwidthLimiter throttles data transfer to a fixed rate. A single
+// limiter is shared by all parallel transfers, so the rate applies to
+// the sum of them and not to each stream.
+type bandwidthLimiter struct {
+	mutex sync.Mutex
+	// Bytes per second.
+	rate float64
+	// Time at which all data reported so far is allowed to be transferred.
+	next time.Time
+}
+
+// newBandwidthLimiter returns a limiter for rate bytes per second.
+func newBandwidthLimiter(rate uint64) *bandwidthLimiter {
+	return &bandwidthLimiter{rate: float64(rate)}
+}
+
+// wait blocks until n more bytes may be transferred.
+func (l *bandwidthLimiter) wait(n int) {
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(float64(n) / l.rate * float64(time.Second)))
+	delay := l.next.Sub(now)
+	l.mutex.Unlock()
+
+	time.Sleep(delay)
+}
+
+// Read implements io.Reader so that the limiter can be used as a hook,
+// it blocks until len(p) bytes may be transferred.
+func (l *bandwidthLimiter) Read(p []byte) (n int, err error) {
+	l.wait(len(p))
+	return len(p), nil
+}
+
+// limitedProgress throttles the data reported to it by all its
+// limiters before reporting it to the progress reader.
+type limitedProgress struct {
+	limiters []*bandwidthLimiter
+	progress io.Reader
+}
+
+// Read implements io.Reader.
+func (lp limitedProgress) Read(p []byte) (n int, err error) {
+	for _, limiter := range lp.limiters {
+		limiter.wait(len(p))
+	}
+	if lp.progress == nil {
+		return len(p), nil
+	}
+	return lp.progress.Read(p)
+}
+
+// newLimitedProgress returns progress throttled by the upload limit if
+// the target is an object storage and by the download limit if the
+// source is an object storage.
+func newLimitedProgress(progress io.Reader, sourceType, targetType clientURLType) io.Reader {
+	var limiters []*bandwidthLimiter
+	if globalLimitDownload != nil && sourceType == objectStorage {
+		limiters = append(limiters, globalLimitDownload)
+	}
+	if globalLimitUpload != nil && targetType == objectStorage {
+		limiters = append(limiters, globalLimitUpload)
+	}
+	if len(limiters) == 0 {
+		return progress
+	}
+	return limitedProgress{limiters: limiters, progress: progress}
+}
+
+// parseBandwidthLimit parses a rate such as '10MiB/s' or '512KB' into
+// bytes per second.
+func parseBandwidthLimit(limit string) (uint64, *probe.Error) {
+	limit = strings.TrimSuffix(strings.TrimSpace(limit), "/s")
+	rate, e := humanize.ParseBytes(limit)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if rate == 0 {
+		return 0, probe.NewError(errors.New("bandwidth limit should be greater than zero"))
+	}
+	return rate, nil
+}
+
+// setBandwidthLimits sets the global upload and download limiters,
+// an empty limit means unlimited.
+func setBandwidthLimits(limitUpload, limitDownload string) *probe.Error {
+	if limitUpload != "" {
+		rate, err := parseBandwidthLimit(limitUpload)
+		if err != nil {
+			return err.Trace(limitUpload)
+		}
+		globalLimitUpload = newBandwidthLimiter(rate)
+	}
+	if limitDownload != "" {
+		rate, err := parseBandwidthLimit(limitDownload)
+		if err != nil {
+			return err.Trace(limitDownload)
+		}
+		globalLimitDownload = newBandwidthLimiter(rate)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/hookreader"
+)
+
+func TestParseBandwidthLimit(t *testing.T) {
+	testCases := []struct {
+		limit   string
+		rate    uint64
+		success bool
+	}{
+		{"10MiB/s", 10 * 1024 * 1024, true},
+		{"10MiB", 10 * 1024 * 1024, true},
+		{" 512KB/s ", 512 * 1000, true},
+		{"1024", 1024, true},
+		{"0", 0, false},
+		{"fast", 0, false},
+		{"", 0, false},
+	}
+	for i, testCase := range testCases {
+		rate, err := parseBandwidthLimit(testCase.limit)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %s", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+		if rate != testCase.rate {
+			t.Errorf("Test %d: expected rate %d, got %d", i+1, testCase.rate, rate)
+		}
+	}
+}
+
+func TestBandwidthLimiterShared(t *testing.T) {
+	limiter := newBandwidthLimiter(1024 * 1024)
+	start := time.Now()
+
+	// Two parallel transfers of 256KiB sharing a 1MiB/s limit
+	// must take around half a second in total.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reader := hookreader.NewHook(bytes.NewReader(make([]byte, 256*1024)), limiter)
+			if _, e := io.Copy(ioutil.Discard, reader); e != nil {
+				t.Error(e)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected transfer to be throttled, took only %s", elapsed)
+	}
+}
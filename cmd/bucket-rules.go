@@ -0,0 +1,210 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// bucketRules is a bucket configuration made of rules with IDs, like
+// the lifecycle and replication configurations.
+type bucketRules interface {
+	// removeRule removes the rule with id, it returns false if there
+	// is no such rule.
+	removeRule(id string) bool
+}
+
+// bucketRulesKind gets and sets one kind of bucket rules, the commands
+// of 'mc ilm' and 'mc replicate' are implemented with it.
+type bucketRulesKind struct {
+	// name of the configuration in messages, e.g. "lifecycle".
+	name string
+	// theme is the console color of the messages.
+	theme string
+	get   func(c *s3Client) (bucketRules, *probe.Error)
+	// set removes the configuration if rules is nil or has no rules.
+	set   func(c *s3Client, rules bucketRules) *probe.Error
+	parse func(data []byte) (bucketRules, *probe.Error)
+	// message returns the message printed after op, id is empty when
+	// op applies to all rules.
+	message func(op, target, id string) message
+}
+
+// newBucketRulesClient returns the S3 client of the bucket at urlStr.
+func newBucketRulesClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	return s3Client
+}
+
+// fetch returns the rules of the bucket at urlStr.
+func (k bucketRulesKind) fetch(urlStr string) bucketRules {
+	rules, err := k.get(newBucketRulesClient(urlStr))
+	fatalIf(err, "Unable to get the "+k.name+" configuration of `"+urlStr+"`.")
+	return rules
+}
+
+// update fetches the rules of the bucket at urlStr, modifies them and
+// sets them back. Elements of the rules mc does not know about are
+// kept.
+func (k bucketRulesKind) update(urlStr string, modify func(rules bucketRules) *probe.Error) {
+	client := newBucketRulesClient(urlStr)
+	rules, err := k.get(client)
+	fatalIf(err, "Unable to get the "+k.name+" configuration of `"+urlStr+"`.")
+	fatalIf(modify(rules), "Invalid "+k.name+" configuration.")
+	fatalIf(k.set(client, rules), "Unable to set the "+k.name+" configuration of `"+urlStr+"`.")
+}
+
+// checkRemoveSyntax - validate the arguments of the remove command.
+func (k bucketRulesKind) checkRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+	isAll := ctx.Bool("all")
+	if (ctx.String("id") == "") == !isAll {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Either --id or --all needs to be passed.")
+	}
+	if isAll && !ctx.Bool("force") {
+		fatalIf(probe.NewError(errors.New("")), "--force flag needs to be passed to remove all "+k.name+" rules.")
+	}
+}
+
+// remove removes the rule given by --id, or all rules with --all.
+func (k bucketRulesKind) remove(ctx *cli.Context) {
+	k.checkRemoveSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	if ctx.Bool("all") {
+		fatalIf(k.set(newBucketRulesClient(urlStr), nil), "Unable to remove the "+k.name+" configuration of `"+urlStr+"`.")
+		printMsg(k.message("remove-all", urlStr, ""))
+		return
+	}
+
+	id := ctx.String("id")
+	k.update(urlStr, func(rules bucketRules) *probe.Error {
+		if !rules.removeRule(id) {
+			fatalIf(errDummy().Trace(urlStr, id), strings.Title(k.name)+" rule `"+id+"` not found on `"+urlStr+"`.")
+		}
+		return nil
+	})
+	printMsg(k.message("remove", urlStr, id))
+}
+
+// importRules replaces the rules of the bucket with the configuration
+// read from FILE, or from STDIN if FILE is not given.
+func (k bucketRulesKind) importRules(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+	}
+
+	urlStr := ctx.Args().Get(0)
+	var data []byte
+	var e error
+	if len(ctx.Args()) == 2 {
+		data, e = ioutil.ReadFile(ctx.Args().Get(1))
+	} else {
+		data, e = ioutil.ReadAll(os.Stdin)
+	}
+	fatalIf(probe.NewError(e).Trace(ctx.Args()...), "Unable to read the "+k.name+" configuration.")
+
+	rules, err := k.parse(data)
+	fatalIf(err, "Invalid "+k.name+" configuration.")
+
+	fatalIf(k.set(newBucketRulesClient(urlStr), rules), "Unable to set the "+k.name+" configuration of `"+urlStr+"`.")
+	printMsg(k.message("import", urlStr, ""))
+}
+
+// export prints the rules of the bucket as JSON.
+func (k bucketRulesKind) export(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+
+	rulesJSONBytes, e := json.MarshalIndent(k.fetch(ctx.Args().Get(0)), "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	console.Println(string(rulesJSONBytes))
+}
+
+// opString returns the message of op on the rule id of target.
+func (k bucketRulesKind) opString(op, target, id string) string {
+	var msg string
+	switch op {
+	case "add":
+		msg = fmt.Sprintf("%s rule `%s` added to `%s`.", strings.Title(k.name), id, target)
+	case "update":
+		msg = fmt.Sprintf("%s rule `%s` updated on `%s`.", strings.Title(k.name), id, target)
+	case "remove":
+		msg = fmt.Sprintf("%s rule `%s` removed from `%s`.", strings.Title(k.name), id, target)
+	case "remove-all":
+		msg = fmt.Sprintf("All %s rules removed from `%s`.", k.name, target)
+	case "import":
+		msg = fmt.Sprintf("%s configuration imported to `%s`.", strings.Title(k.name), target)
+	case "empty":
+		msg = fmt.Sprintf("No %s rules set on `%s`.", k.name, target)
+	default:
+		return ""
+	}
+	return console.Colorize(k.theme, msg)
+}
+
+// rulesColumn is a column of the tables listing bucket rules.
+type rulesColumn struct {
+	title  string
+	maxLen int
+}
+
+// rulesTable lists bucket rules, empty values are shown as "-".
+type rulesTable []rulesColumn
+
+// printHeaders prints the titles of the columns.
+func (t rulesTable) printHeaders() {
+	fields := make([]Field, len(t))
+	titles := make([]string, len(t))
+	for i, column := range t {
+		fields[i] = Field{"", column.maxLen}
+		titles[i] = column.title
+	}
+	console.Println(console.Colorize("Headers", newPrettyTable("  ", fields...).buildRow(titles...)))
+}
+
+// buildRow returns the row of a rule.
+func (t rulesTable) buildRow(values ...string) string {
+	fields := make([]Field, len(t))
+	for i, column := range t {
+		fields[i] = Field{column.title, column.maxLen}
+	}
+	for i := range values {
+		if values[i] == "" {
+			values[i] = "-"
+		}
+	}
+	return newPrettyTable("  ", fields...).buildRow(values...)
+}
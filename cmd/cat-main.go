@@ -32,10 +32,12 @@ import (
 )
 
 var (
-	// This is kept dummy for future purposes
-	// and also to add ioFlags and globalFlags
-	// in CLI registration.
-	catFlags = []cli.Flag{}
+	catFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "version-id",
+			Usage: "display a specific version of the object",
+		},
+	}
 )
 
 // Display contents of a file.
@@ -73,6 +75,9 @@ EXAMPLES:
   5. Display the content of encrypted object. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      $ {{.HelpName}} --encrypt-key "play/my-bucket/=MzJieXRlc2xvbmdzZWNyZXRrZQltdXN0YmVnaXZlbjE="  play/my-bucket/my-object
+
+  6. Display the content of a specific version of an object.
+     $ {{.HelpName}} --version-id "3a6c3e5f-24a1-4ed4-9c0f-a4f5e6f4e2b1" s3/mybucket/myobject.txt
 `,
 }
 
@@ -136,10 +141,14 @@ func checkCatSyntax(ctx *cli.Context) {
 			fatalIf(probe.NewError(errors.New("")), fmt.Sprintf("Unknown flag `%s` passed.", arg))
 		}
 	}
+	if ctx.String("version-id") != "" && (len(args) != 1 || args[0] == "-") {
+		fatalIf(errInvalidArgument().Trace(args...), "A version can only be displayed for a single object.")
+	}
 }
 
-// catURL displays contents of a URL to stdout.
-func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+// catURL displays contents of a URL to stdout, of a specific version
+// of the object if versionID is not empty.
+func catURL(sourceURL, versionID string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -152,11 +161,17 @@ func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error
 		// downloaded object is equal to the original one. FS files
 		// are ignored since some of them have zero size though they
 		// have contents like files under /proc.
-		client, content, err := url2Stat(sourceURL, false, encKeyDB)
+		var client Client
+		var content *clientContent
+		if versionID != "" {
+			client, content, err = url2StatVersion(sourceURL, versionID, encKeyDB)
+		} else {
+			client, content, err = url2Stat(sourceURL, false, encKeyDB)
+		}
 		if err == nil && client.GetURL().Type == objectStorage {
 			size = content.Size
 		}
-		if reader, err = getSourceStreamFromURL(sourceURL, encKeyDB); err != nil {
+		if reader, err = getSourceStreamFromURL(sourceURL, versionID, encKeyDB); err != nil {
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
@@ -242,9 +257,11 @@ func mainCat(ctx *cli.Context) error {
 		}
 	}
 
+	versionID := ctx.String("version-id")
+
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(url, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(url, versionID, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
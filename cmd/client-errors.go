@@ -56,6 +56,13 @@ func (e BucketNameEmpty) Error() string {
 	return "Bucket name cannot be empty."
 }
 
+// ObjectNameEmpty - object name empty.
+type ObjectNameEmpty struct{}
+
+func (e ObjectNameEmpty) Error() string {
+	return "Object name cannot be empty."
+}
+
 // BucketInvalid - bucket name invalid.
 type BucketInvalid struct {
 	Bucket string
@@ -191,3 +198,14 @@ type SameFile struct {
 func (e SameFile) Error() string {
 	return fmt.Sprintf("'%s' and '%s' are the same file", e.Source, e.Destination)
 }
+
+// ObjectRemoveFailed - an object could not be removed, Path is the path
+// of the object as in the URLs of listed objects.
+type ObjectRemoveFailed struct {
+	Path string
+	Err  error
+}
+
+func (e ObjectRemoveFailed) Error() string {
+	return e.Err.Error()
+}
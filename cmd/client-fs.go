@@ -35,6 +35,8 @@ import (
 	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/ioutils"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/mc/pkg/s3select"
+	minio "github.com/minio/minio-go/v6"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
 
@@ -112,9 +114,143 @@ func (f *fsClient) GetURL() clientURL {
 	return *f.PathURL
 }
 
-// Select replies a stream of query results.
+// Select replies a stream of query results, the query is evaluated
+// locally with the same SQL subset as the S3 Select API.
 func (f *fsClient) Select(expression string, sse encrypt.ServerSide, opts SelectObjectOpts) (io.ReadCloser, *probe.Error) {
-	return nil, probe.NewError(APINotImplemented{})
+	query, e := s3select.Parse(expression)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	reader, err := f.Get(sse)
+	if err != nil {
+		return nil, err.Trace(f.PathURL.Path)
+	}
+	input := selectObjectInputOpts(opts, f.PathURL.Path)
+	output := selectObjectOutputOpts(opts, input)
+	pr, pw := io.Pipe()
+	results := &fsSelectResults{PipeReader: pr}
+	go func() {
+		defer reader.Close()
+		stats, e := query.Run(reader, input, output, pw)
+		results.stats = minio.StatsMessage{
+			BytesScanned:   stats.BytesScanned,
+			BytesProcessed: stats.BytesProcessed,
+			BytesReturned:  stats.BytesReturned,
+		}
+		pw.CloseWithError(e)
+	}()
+	return results, nil
+}
+
+// fsSelectResults streams the results of a local select, the stats are
+// complete once the stream is read to its end.
+type fsSelectResults struct {
+	*io.PipeReader
+	stats minio.StatsMessage
+}
+
+// Stats returns the bytes scanned, processed and returned by the query.
+func (r *fsSelectResults) Stats() *minio.StatsMessage {
+	return &r.stats
+}
+
+// ListVersions - versioning not implemented for filesystem.
+func (f *fsClient) ListVersions(isRecursive bool) <-chan *clientContent {
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{Err: probe.NewError(APINotImplemented{
+		API:     "ListVersions",
+		APIType: "filesystem",
+	})}
+	close(contentCh)
+	return contentCh
+}
+
+// StatVersion - versioning not implemented for filesystem.
+func (f *fsClient) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "StatVersion",
+		APIType: "filesystem",
+	})
+}
+
+// GetVersion - versioning not implemented for filesystem.
+func (f *fsClient) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetVersion",
+		APIType: "filesystem",
+	})
+}
+
+// RemoveVersion - versioning not implemented for filesystem.
+func (f *fsClient) RemoveVersion(versionID string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "RemoveVersion",
+		APIType: "filesystem",
+	})
+}
+
+// GetVersioning - versioning not implemented for filesystem.
+func (f *fsClient) GetVersioning() (string, *probe.Error) {
+	return "", probe.NewError(APINotImplemented{
+		API:     "GetVersioning",
+		APIType: "filesystem",
+	})
+}
+
+// SetVersioning - versioning not implemented for filesystem.
+func (f *fsClient) SetVersioning(status string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetVersioning",
+		APIType: "filesystem",
+	})
+}
+
+// GetTags - tagging not implemented for filesystem.
+func (f *fsClient) GetTags() ([]objectTag, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetTags",
+		APIType: "filesystem",
+	})
+}
+
+// SetTags - tagging not implemented for filesystem.
+func (f *fsClient) SetTags(tags []objectTag) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetTags",
+		APIType: "filesystem",
+	})
+}
+
+// GetRetention - object lock not implemented for filesystem.
+func (f *fsClient) GetRetention() (string, time.Time, *probe.Error) {
+	return "", time.Time{}, probe.NewError(APINotImplemented{
+		API:     "GetRetention",
+		APIType: "filesystem",
+	})
+}
+
+// SetRetention - object lock not implemented for filesystem.
+func (f *fsClient) SetRetention(mode string, retainUntil time.Time, bypassGovernance bool) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetRetention",
+		APIType: "filesystem",
+	})
+}
+
+// GetLegalHold - object lock not implemented for filesystem.
+func (f *fsClient) GetLegalHold() (string, *probe.Error) {
+	return "", probe.NewError(APINotImplemented{
+		API:     "GetLegalHold",
+		APIType: "filesystem",
+	})
+}
+
+// SetLegalHold - object lock not implemented for filesystem.
+func (f *fsClient) SetLegalHold(status string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetLegalHold",
+		APIType: "filesystem",
+	})
 }
 
 // Watches for all fs events on an input path.
@@ -932,7 +1068,13 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 }
 
 // MakeBucket - create a new bucket.
-func (f *fsClient) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+func (f *fsClient) MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error {
+	if withLock {
+		return probe.NewError(APINotImplemented{
+			API:     "MakeBucketWithLock",
+			APIType: "filesystem",
+		})
+	}
 	// TODO: ignoreExisting has no effect currently. In the future, we want
 	// to call os.Mkdir() when ignoredExisting is disabled and os.MkdirAll()
 	// otherwise.
@@ -194,7 +194,7 @@ func (s *TestSuite) TestPutBucket(c *C) {
 	bucketPath := filepath.Join(root, "bucket")
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 }
 
@@ -208,7 +208,7 @@ func (s *TestSuite) TestStatBucket(c *C) {
 
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 	_, err = fsClient.Stat(false, false, nil)
 	c.Assert(err, IsNil)
@@ -223,7 +223,7 @@ func (s *TestSuite) TestBucketACLFails(c *C) {
 	bucketPath := filepath.Join(root, "bucket")
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 
 	// On windows setting permissions is not supported.
@@ -0,0 +1,257 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Versioning states of a bucket.
+const (
+	versioningEnabled   = "Enabled"
+	versioningSuspended = "Suspended"
+)
+
+// versioningConfiguration is the versioning state of a bucket, Status
+// is empty if versioning was never enabled.
+type versioningConfiguration struct {
+	XMLName   xml.Name `xml:"VersioningConfiguration"`
+	Status    string   `xml:"Status,omitempty"`
+	MFADelete string   `xml:"MfaDelete,omitempty"`
+}
+
+// encryptionConfiguration is the default encryption of the objects
+// of a bucket.
+type encryptionConfiguration struct {
+	XMLName xml.Name         `xml:"ServerSideEncryptionConfiguration"`
+	Rules   []encryptionRule `xml:"Rule"`
+}
+
+// encryptionRule applies a server side encryption to new objects.
+type encryptionRule struct {
+	Apply struct {
+		SSEAlgorithm   string `xml:"SSEAlgorithm"`
+		KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+	} `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// Default encryption algorithms of a bucket.
+const (
+	encryptionSSES3  = "AES256"
+	encryptionSSEKMS = "aws:kms"
+)
+
+// objectLockConfiguration is the object lock configuration of a
+// bucket, with its optional default retention.
+type objectLockConfiguration struct {
+	XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string   `xml:"ObjectLockEnabled,omitempty"`
+	Rule              *struct {
+		DefaultRetention struct {
+			Mode  string `xml:"Mode"`
+			Days  int    `xml:"Days,omitempty"`
+			Years int    `xml:"Years,omitempty"`
+		} `xml:"DefaultRetention"`
+	} `xml:"Rule,omitempty"`
+}
+
+// bucketRequest returns a request on a sub-resource of the bucket.
+func (c *s3Client) bucketRequest(method, subResource string) (s3Request, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return s3Request{}, probe.NewError(BucketNameEmpty{})
+	}
+	return s3Request{
+		method: method,
+		bucket: bucket,
+		query:  url.Values{subResource: []string{""}},
+	}, nil
+}
+
+// getBucketConfig decodes a bucket sub-resource into v, found is false
+// if the server replies with notFoundCode.
+func (c *s3Client) getBucketConfig(subResource, notFoundCode string, v interface{}) (found bool, err *probe.Error) {
+	req, err := c.bucketRequest(http.MethodGet, subResource)
+	if err != nil {
+		return false, err
+	}
+	if err = c.executeRequestXML(req, v); err != nil {
+		if notFoundCode != "" && minio.ToErrorResponse(err.ToGoError()).Code == notFoundCode {
+			return false, nil
+		}
+		return false, err.Trace(req.bucket, subResource)
+	}
+	return true, nil
+}
+
+// GetRegion - get the region of the bucket.
+func (c *s3Client) GetRegion() (string, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", probe.NewError(BucketNameEmpty{})
+	}
+	return c.bucketRegion(bucket)
+}
+
+// GetVersioning - get the versioning state of the bucket, it is empty
+// if versioning was never enabled.
+func (c *s3Client) GetVersioning() (string, *probe.Error) {
+	var config versioningConfiguration
+	if _, err := c.getBucketConfig("versioning", "", &config); err != nil {
+		return "", err
+	}
+	return config.Status, nil
+}
+
+// SetVersioning - enable or suspend versioning of the bucket.
+func (c *s3Client) SetVersioning(status string) *probe.Error {
+	req, err := c.bucketRequest(http.MethodPut, "versioning")
+	if err != nil {
+		return err
+	}
+	body, e := xml.Marshal(versioningConfiguration{Status: status})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(req.bucket, status)
+}
+
+// GetEncryption - get the default encryption of the bucket, nil is
+// returned if it is not set.
+func (c *s3Client) GetEncryption() (*encryptionConfiguration, *probe.Error) {
+	config := &encryptionConfiguration{}
+	found, err := c.getBucketConfig("encryption", "ServerSideEncryptionConfigurationNotFoundError", config)
+	if err != nil || !found {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SetEncryption - set the default encryption of the bucket, kmsKeyID
+// is only used with the SSE-KMS algorithm.
+func (c *s3Client) SetEncryption(algorithm, kmsKeyID string) *probe.Error {
+	req, err := c.bucketRequest(http.MethodPut, "encryption")
+	if err != nil {
+		return err
+	}
+	var rule encryptionRule
+	rule.Apply.SSEAlgorithm = algorithm
+	rule.Apply.KMSMasterKeyID = kmsKeyID
+	body, e := xml.Marshal(encryptionConfiguration{Rules: []encryptionRule{rule}})
+	if e != nil {
+		return probe.NewError(e).Trace(req.bucket)
+	}
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(req.bucket, algorithm)
+}
+
+// DeleteEncryption - remove the default encryption of the bucket.
+func (c *s3Client) DeleteEncryption() *probe.Error {
+	req, err := c.bucketRequest(http.MethodDelete, "encryption")
+	if err != nil {
+		return err
+	}
+	return c.executeRequestXML(req, nil).Trace(req.bucket)
+}
+
+// GetObjectLockConfig - get the object lock configuration of the
+// bucket, nil is returned if object lock is not enabled.
+func (c *s3Client) GetObjectLockConfig() (*objectLockConfiguration, *probe.Error) {
+	config := &objectLockConfiguration{}
+	found, err := c.getBucketConfig("object-lock", "ObjectLockConfigurationNotFoundError", config)
+	if err != nil || !found {
+		return nil, err
+	}
+	return config, nil
+}
+
+// GetReplication - get the replication configuration of the bucket,
+// it has no rules if none is set. Legacy V1 rules are kept as read.
+func (c *s3Client) GetReplication() (*replicationConfiguration, *probe.Error) {
+	replication := &replicationConfiguration{}
+	found, err := c.getBucketConfig("replication", "ReplicationConfigurationNotFoundError", replication)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return &replicationConfiguration{Rules: []replicationRule{}}, nil
+	}
+	return replication, nil
+}
+
+// SetReplication - set the replication configuration of the bucket,
+// it is removed if there are no rules.
+func (c *s3Client) SetReplication(replication *replicationConfiguration) *probe.Error {
+	if replication == nil || len(replication.Rules) == 0 {
+		req, err := c.bucketRequest(http.MethodDelete, "replication")
+		if err != nil {
+			return err
+		}
+		return c.executeRequestXML(req, nil).Trace(req.bucket)
+	}
+	req, err := c.bucketRequest(http.MethodPut, "replication")
+	if err != nil {
+		return err
+	}
+	body, e := xml.Marshal(replication)
+	if e != nil {
+		return probe.NewError(e).Trace(req.bucket)
+	}
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(req.bucket)
+}
+
+// GetCORS - get the CORS configuration of the bucket, nil is returned
+// if it is not set.
+func (c *s3Client) GetCORS() (*corsConfiguration, *probe.Error) {
+	cors := &corsConfiguration{}
+	found, err := c.getBucketConfig("cors", "NoSuchCORSConfiguration", cors)
+	if err != nil || !found {
+		return nil, err
+	}
+	return cors, nil
+}
+
+// SetCORS - set the CORS configuration of the bucket, it replaces all
+// existing CORS rules.
+func (c *s3Client) SetCORS(cors *corsConfiguration) *probe.Error {
+	req, err := c.bucketRequest(http.MethodPut, "cors")
+	if err != nil {
+		return err
+	}
+	body, e := xml.Marshal(cors)
+	if e != nil {
+		return probe.NewError(e).Trace(req.bucket)
+	}
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(req.bucket)
+}
+
+// DeleteCORS - remove the CORS configuration of the bucket.
+func (c *s3Client) DeleteCORS() *probe.Error {
+	req, err := c.bucketRequest(http.MethodDelete, "cors")
+	if err != nil {
+		return err
+	}
+	return c.executeRequestXML(req, nil).Trace(req.bucket)
+}
@@ -0,0 +1,150 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// createBucketConfiguration is the location of a new bucket.
+type createBucketConfiguration struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CreateBucketConfiguration"`
+	Location string   `xml:"LocationConstraint"`
+}
+
+// makeBucketWithLock - create a bucket with object lock enabled, object
+// lock can only be enabled when a bucket is created.
+func (c *s3Client) makeBucketWithLock(bucket, region string) *probe.Error {
+	if region == "" {
+		region = "us-east-1"
+	}
+	req := s3Request{
+		method: http.MethodPut,
+		bucket: bucket,
+		header: http.Header{"X-Amz-Bucket-Object-Lock-Enabled": []string{"true"}},
+		region: region,
+	}
+	if region != "us-east-1" {
+		body, e := xml.Marshal(createBucketConfiguration{Location: region})
+		if e != nil {
+			return probe.NewError(e)
+		}
+		req.body = body
+	}
+	return c.executeRequestXML(req, nil).Trace(bucket, region)
+}
+
+// lockRequest returns a request on the object lock sub-resource of
+// the object.
+func (c *s3Client) lockRequest(method, subResource string) (s3Request, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return s3Request{}, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return s3Request{}, probe.NewError(ObjectNameEmpty{})
+	}
+	return s3Request{
+		method: method,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{subResource: []string{""}},
+	}, nil
+}
+
+// GetRetention - get the retention mode and date of the object, an
+// empty mode is returned when the object is not retained.
+func (c *s3Client) GetRetention() (string, time.Time, *probe.Error) {
+	req, err := c.lockRequest(http.MethodGet, "retention")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	retention := objectRetention{}
+	if err = c.executeRequestXML(req, &retention); err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchObjectLockConfiguration" {
+			return "", time.Time{}, nil
+		}
+		return "", time.Time{}, err.Trace(req.bucket, req.object)
+	}
+	if retention.RetainUntilDate == nil {
+		return retention.Mode, time.Time{}, nil
+	}
+	return retention.Mode, *retention.RetainUntilDate, nil
+}
+
+// SetRetention - set the retention mode and date of the object, an
+// empty mode removes the retention. Governance retention can only be
+// shortened or removed with bypassGovernance.
+func (c *s3Client) SetRetention(mode string, retainUntil time.Time, bypassGovernance bool) *probe.Error {
+	req, err := c.lockRequest(http.MethodPut, "retention")
+	if err != nil {
+		return err
+	}
+	retention := objectRetention{Mode: mode}
+	if mode != "" {
+		retainUntil = retainUntil.UTC()
+		retention.RetainUntilDate = &retainUntil
+	}
+	body, e := xml.Marshal(retention)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.body = body
+	if bypassGovernance {
+		req.header = http.Header{"X-Amz-Bypass-Governance-Retention": []string{"true"}}
+	}
+	return c.executeRequestXML(req, nil).Trace(req.bucket, req.object)
+}
+
+// GetLegalHold - get the legal hold status of the object.
+func (c *s3Client) GetLegalHold() (string, *probe.Error) {
+	req, err := c.lockRequest(http.MethodGet, "legal-hold")
+	if err != nil {
+		return "", err
+	}
+	legalHold := objectLegalHold{}
+	if err = c.executeRequestXML(req, &legalHold); err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchObjectLockConfiguration" {
+			return legalHoldOff, nil
+		}
+		return "", err.Trace(req.bucket, req.object)
+	}
+	if legalHold.Status == "" {
+		return legalHoldOff, nil
+	}
+	return legalHold.Status, nil
+}
+
+// SetLegalHold - set the legal hold status of the object.
+func (c *s3Client) SetLegalHold(status string) *probe.Error {
+	req, err := c.lockRequest(http.MethodPut, "legal-hold")
+	if err != nil {
+		return err
+	}
+	body, e := xml.Marshal(objectLegalHold{Status: status})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(req.bucket, req.object)
+}
@@ -0,0 +1,193 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/s3signer"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+)
+
+// s3Request describes a request to an S3 API which is not supported
+// by minio-go, such as object versions or bucket sub-resources.
+type s3Request struct {
+	method string
+	bucket string
+	object string
+	query  url.Values
+	header http.Header
+	body   []byte
+	// region used to sign the request, the region of the bucket is
+	// looked up if not set.
+	region string
+}
+
+// requestURL returns the URL of the bucket or object of the request,
+// virtual host style is used when the target uses it.
+func (c *s3Client) requestURL(req s3Request) (*url.URL, *probe.Error) {
+	host := c.endpointURL.Host
+	urlStr := c.endpointURL.Scheme + "://" + host + "/"
+	if req.bucket != "" {
+		if c.virtualStyle {
+			if !strings.HasPrefix(host, req.bucket+".") {
+				host = req.bucket + "." + host
+			}
+			urlStr = c.endpointURL.Scheme + "://" + host + "/"
+		} else {
+			urlStr += req.bucket + "/"
+		}
+		urlStr += s3utils.EncodePath(req.object)
+	}
+	if len(req.query) > 0 {
+		urlStr += "?" + s3utils.QueryEncode(req.query)
+	}
+	u, e := url.Parse(urlStr)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return u, nil
+}
+
+// bucketRegion returns the region used to sign requests to bucket.
+func (c *s3Client) bucketRegion(bucket string) (string, *probe.Error) {
+	if bucket == "" {
+		return "us-east-1", nil
+	}
+	region, e := c.api.GetBucketLocation(bucket)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return region, nil
+}
+
+// executeRequest signs and sends the request, the caller must close
+// the body of the response. Responses other than 2xx are returned as
+// minio.ErrorResponse errors.
+func (c *s3Client) executeRequest(req s3Request) (*http.Response, *probe.Error) {
+	u, err := c.requestURL(req)
+	if err != nil {
+		return nil, err.Trace(req.bucket, req.object)
+	}
+	region := req.region
+	if region == "" {
+		if region, err = c.bucketRegion(req.bucket); err != nil {
+			return nil, err.Trace(req.bucket)
+		}
+	}
+
+	httpReq, e := http.NewRequest(req.method, u.String(), bytes.NewReader(req.body))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for k, v := range req.header {
+		httpReq.Header[k] = v
+	}
+	httpReq.ContentLength = int64(len(req.body))
+	if len(req.body) > 0 {
+		md5Sum := md5.Sum(req.body)
+		httpReq.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+	}
+	sha256Sum := sha256.Sum256(req.body)
+	httpReq.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum[:]))
+	httpReq.Header.Set("User-Agent", "MinIO ("+c.config.AppName+"; "+c.config.AppVersion+")")
+
+	if c.config.AccessKey != "" || c.config.SecretKey != "" {
+		if strings.EqualFold(c.config.Signature, "S3v2") {
+			httpReq = s3signer.SignV2(*httpReq, c.config.AccessKey, c.config.SecretKey, c.virtualStyle)
+		} else {
+			httpReq = s3signer.SignV4(*httpReq, c.config.AccessKey, c.config.SecretKey, "", region)
+		}
+	}
+
+	resp, e := (&http.Client{Transport: c.transport}).Do(httpReq)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, probe.NewError(httpRespToErrorResponse(resp, req.bucket, req.object))
+	}
+	return resp, nil
+}
+
+// executeRequestXML sends the request and decodes the XML response
+// into v, if v is not nil.
+func (c *s3Client) executeRequestXML(req s3Request, v interface{}) *probe.Error {
+	resp, err := c.executeRequest(req)
+	if err != nil {
+		return err.Trace(req.bucket, req.object)
+	}
+	defer resp.Body.Close()
+	if v == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	if e := xml.NewDecoder(resp.Body).Decode(v); e != nil {
+		return probe.NewError(e).Trace(req.bucket, req.object)
+	}
+	return nil
+}
+
+// httpRespToErrorResponse converts an error response into an error,
+// responses to HEAD requests carry no body so the error code is then
+// derived from the status code.
+func httpRespToErrorResponse(resp *http.Response, bucket, object string) minio.ErrorResponse {
+	errResp := minio.ErrorResponse{}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if len(body) > 0 {
+		xml.Unmarshal(body, &errResp)
+	}
+	errResp.StatusCode = resp.StatusCode
+	if errResp.Code == "" {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			if object == "" {
+				errResp.Code = "NoSuchBucket"
+			} else {
+				errResp.Code = "NoSuchKey"
+			}
+		case http.StatusForbidden:
+			errResp.Code = "AccessDenied"
+		case http.StatusMethodNotAllowed:
+			errResp.Code = "MethodNotAllowed"
+		default:
+			errResp.Code = strconv.Itoa(resp.StatusCode)
+		}
+		errResp.Message = resp.Status
+	}
+	if errResp.BucketName == "" {
+		errResp.BucketName = bucket
+	}
+	if errResp.Key == "" {
+		errResp.Key = object
+	}
+	return errResp
+}
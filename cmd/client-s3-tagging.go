@@ -0,0 +1,82 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// GetTags - get the tags of the object, or of the bucket if the url
+// points to a bucket.
+func (c *s3Client) GetTags() ([]objectTag, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	t := tagging{}
+	err := c.executeRequestXML(s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"tagging": []string{""}},
+	}, &t)
+	if err != nil {
+		// Buckets without tags have no tag set.
+		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchTagSet" {
+			return nil, nil
+		}
+		return nil, err.Trace(bucket, object)
+	}
+	return t.TagSet.Tags, nil
+}
+
+// SetTags - replace the tags of the object, or of the bucket if the
+// url points to a bucket. No tags removes all tags.
+func (c *s3Client) SetTags(tags []objectTag) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	req := s3Request{
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"tagging": []string{""}},
+	}
+	if len(tags) == 0 {
+		req.method = http.MethodDelete
+		return c.executeRequestXML(req, nil).Trace(bucket, object)
+	}
+
+	if err := validateTags(tags, object == ""); err != nil {
+		return err.Trace(bucket, object)
+	}
+
+	t := tagging{}
+	t.TagSet.Tags = tags
+	body, e := xml.Marshal(t)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.method = http.MethodPut
+	req.body = body
+	return c.executeRequestXML(req, nil).Trace(bucket, object)
+}
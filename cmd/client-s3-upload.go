@@ -0,0 +1,290 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/mc/pkg/hookreader"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+)
+
+// Objects with tags are uploaded and copied with requests made by mc,
+// minio-go cannot send the tagging header of PutObject, CopyObject and
+// CreateMultipartUpload. The tags are set along with the data so that
+// the object is never visible without them. Copies of objects larger
+// than maxCopyObjectSize are made by mc too, minio-go does not return
+// the ID of the multipart upload of a failed copy so it cannot be
+// aborted without aborting the uploads of other clients.
+
+// Size of the parts of tagged uploads, smaller objects are uploaded
+// with a single request.
+const taggedUploadPartSize = 64 * 1024 * 1024
+
+// Maximum number of parts of a multipart upload.
+const maxUploadParts = 10000
+
+// initiateMultipartUploadResult is the response to CreateMultipartUpload.
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+// tagsHeader returns the value of the X-Amz-Tagging header setting the
+// tags given in the form "key1=value1&key2=value2".
+func tagsHeader(tagsStr string) (string, error) {
+	tags, err := parseTags(tagsStr)
+	if err != nil {
+		return "", err.ToGoError()
+	}
+	if err = validateTags(tags, false); err != nil {
+		return "", err.ToGoError()
+	}
+	values := make(url.Values)
+	for _, tag := range tags {
+		values.Set(tag.Key, tag.Value)
+	}
+	return s3utils.QueryEncode(values), nil
+}
+
+// newUpload initiates a multipart upload with header and returns its ID.
+func (c *s3Client) newUpload(bucket, object string, header http.Header) (string, error) {
+	result := initiateMultipartUploadResult{}
+	err := c.executeRequestXML(s3Request{
+		method: http.MethodPost,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"uploads": []string{""}},
+		header: header,
+	}, &result)
+	if err != nil {
+		return "", err.ToGoError()
+	}
+	return result.UploadID, nil
+}
+
+// abortUpload aborts the multipart upload uploadID which failed with
+// e, only this upload is aborted as other clients may be uploading the
+// same object.
+func (c *s3Client) abortUpload(bucket, object, uploadID string, e error) error {
+	core := minio.Core{Client: c.api}
+	if abortErr := core.AbortMultipartUpload(bucket, object, uploadID); abortErr != nil {
+		return fmt.Errorf("%v, unable to abort the incomplete upload `%s`: %v", e, uploadID, abortErr)
+	}
+	return e
+}
+
+// putTagged uploads an object with tagsStr like PutObjectWithContext
+// does with opts.
+func (c *s3Client) putTagged(bucket, object string, reader io.Reader, size int64, tagsStr string, opts minio.PutObjectOptions) (int64, error) {
+	tagging, e := tagsHeader(tagsStr)
+	if e != nil {
+		return 0, e
+	}
+	header := opts.Header()
+	header.Set(tagsMetadataKey, tagging)
+
+	if opts.Progress != nil {
+		reader = hookreader.NewHook(reader, opts.Progress)
+	}
+	partSize := int64(taggedUploadPartSize)
+	if size >= 0 {
+		reader = io.LimitReader(reader, size)
+		if size > partSize*maxUploadParts {
+			partSize = (size + maxUploadParts - 1) / maxUploadParts
+		} else if size < partSize {
+			partSize = size
+		}
+	}
+
+	buf := make([]byte, partSize)
+	n, e := io.ReadFull(reader, buf)
+	last := e == io.EOF || e == io.ErrUnexpectedEOF || int64(n) == size
+	if e != nil && !last {
+		return 0, e
+	}
+	if last {
+		if size >= 0 && int64(n) != size {
+			return int64(n), io.ErrUnexpectedEOF
+		}
+		resp, err := c.executeRequest(s3Request{
+			method: http.MethodPut,
+			bucket: bucket,
+			object: object,
+			header: header,
+			body:   buf[:n],
+		})
+		if err != nil {
+			return 0, err.ToGoError()
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		return int64(n), nil
+	}
+
+	uploadID, e := c.newUpload(bucket, object, header)
+	if e != nil {
+		return 0, e
+	}
+	core := minio.Core{Client: c.api}
+	var parts []minio.CompletePart
+	var total int64
+	for partNumber := 1; n > 0; partNumber++ {
+		part, e := core.PutObjectPart(bucket, object, uploadID, partNumber, bytes.NewReader(buf[:n]), int64(n), "", "", opts.ServerSideEncryption)
+		if e != nil {
+			return total, c.abortUpload(bucket, object, uploadID, e)
+		}
+		total += int64(n)
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+		if last {
+			break
+		}
+		n, e = io.ReadFull(reader, buf)
+		last = e == io.ErrUnexpectedEOF
+		if e != nil && e != io.EOF && !last {
+			return total, c.abortUpload(bucket, object, uploadID, e)
+		}
+	}
+	if size >= 0 && total != size {
+		return total, c.abortUpload(bucket, object, uploadID, io.ErrUnexpectedEOF)
+	}
+	if _, e = core.CompleteMultipartUpload(bucket, object, uploadID, parts); e != nil {
+		return total, c.abortUpload(bucket, object, uploadID, e)
+	}
+	return total, nil
+}
+
+// copyHeader returns the headers of a copy setting metadata and the
+// encryption of the target, the metadata of the source is kept if
+// metadata is empty.
+func copyHeader(metadata map[string]string, tgtSSE encrypt.ServerSide) http.Header {
+	header := minio.PutObjectOptions{UserMetadata: metadata, ServerSideEncryption: tgtSSE}.Header()
+	hasContentType := false
+	for k := range metadata {
+		if strings.EqualFold(k, "Content-Type") {
+			hasContentType = true
+		}
+	}
+	if !hasContentType {
+		header.Del("Content-Type")
+	}
+	if len(metadata) > 0 {
+		header.Set("X-Amz-Metadata-Directive", "REPLACE")
+	}
+	return header
+}
+
+// copyObject copies an object with tagsStr if not empty, objects
+// larger than maxCopyObjectSize are copied in parts and only the
+// multipart upload of this copy is aborted if it fails.
+func (c *s3Client) copyObject(srcBucket, srcObject, dstBucket, dstObject string, size int64, tagsStr string, metadata map[string]string, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide) error {
+	header := copyHeader(metadata, tgtSSE)
+	if tagsStr != "" {
+		tagging, e := tagsHeader(tagsStr)
+		if e != nil {
+			return e
+		}
+		header.Set(tagsMetadataKey, tagging)
+		header.Set("X-Amz-Tagging-Directive", "REPLACE")
+	}
+	core := minio.Core{Client: c.api}
+
+	// Headers needed to read the source and to write the target
+	// encrypted with keys given by the client.
+	sseHeader := make(http.Header)
+	if srcSSE != nil {
+		encrypt.SSECopy(srcSSE).Marshal(sseHeader)
+	}
+	if tgtSSE != nil && tgtSSE.Type() == encrypt.SSEC {
+		tgtSSE.Marshal(sseHeader)
+	}
+
+	if size <= maxCopyObjectSize {
+		for k := range sseHeader {
+			header.Set(k, sseHeader.Get(k))
+		}
+		if _, e := core.CopyObject(srcBucket, srcObject, dstBucket, dstObject, headerMap(header)); e != nil {
+			return e
+		}
+		if progress != nil {
+			io.CopyN(ioutil.Discard, progress, size)
+		}
+		return nil
+	}
+
+	// The source must not change while its parts are copied.
+	info, e := core.StatObject(srcBucket, srcObject, minio.StatObjectOptions{
+		GetObjectOptions: minio.GetObjectOptions{ServerSideEncryption: srcSSE},
+	})
+	if e != nil {
+		return e
+	}
+	if len(metadata) == 0 {
+		header.Set("Content-Type", info.ContentType)
+		for k, v := range info.Metadata {
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") && len(v) > 0 {
+				header.Set(k, v[0])
+			}
+		}
+	}
+	header.Del("X-Amz-Metadata-Directive")
+	header.Del("X-Amz-Tagging-Directive")
+	uploadID, e := c.newUpload(dstBucket, dstObject, header)
+	if e != nil {
+		return e
+	}
+
+	partHeader := headerMap(sseHeader)
+	partHeader["X-Amz-Copy-Source-If-Match"] = info.ETag
+	partsCount := (size + maxCopyObjectSize - 1) / maxCopyObjectSize
+	partSize := (size + partsCount - 1) / partsCount
+	var parts []minio.CompletePart
+	for start, partNumber := int64(0), 1; start < size; start, partNumber = start+partSize, partNumber+1 {
+		length := partSize
+		if start+length > size {
+			length = size - start
+		}
+		part, e := core.CopyObjectPart(srcBucket, srcObject, dstBucket, dstObject, uploadID, partNumber, start, length, partHeader)
+		if e != nil {
+			return c.abortUpload(dstBucket, dstObject, uploadID, e)
+		}
+		if progress != nil {
+			io.CopyN(ioutil.Discard, progress, length)
+		}
+		parts = append(parts, part)
+	}
+	if _, e = core.CompleteMultipartUpload(dstBucket, dstObject, uploadID, parts); e != nil {
+		return c.abortUpload(dstBucket, dstObject, uploadID, e)
+	}
+	return nil
+}
+
+// headerMap returns the first value of each header.
+func headerMap(header http.Header) map[string]string {
+	m := make(map[string]string, len(header))
+	for k := range header {
+		m[k] = header.Get(k)
+	}
+	return m
+}
@@ -0,0 +1,242 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// objectVersion is a version or a delete marker of an object, as
+// returned by ListObjectVersions.
+type objectVersion struct {
+	XMLName      xml.Name
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified time.Time
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+// listVersionsResult is the response of ListObjectVersions. Versions
+// and delete markers are decoded together to preserve their order.
+type listVersionsResult struct {
+	Name                string
+	Prefix              string
+	KeyMarker           string
+	VersionIDMarker     string `xml:"VersionIdMarker"`
+	NextKeyMarker       string
+	NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+	MaxKeys             int
+	Delimiter           string
+	EncodingType        string
+	IsTruncated         bool
+	CommonPrefixes      []struct {
+		Prefix string
+	}
+	Entries []objectVersion `xml:",any"`
+}
+
+// Headers which are not object metadata.
+var nonMetadataHeaders = []string{
+	"Accept-Ranges",
+	"Connection",
+	"Content-Length",
+	"Content-Security-Policy",
+	"Date",
+	"Etag",
+	"Expires",
+	"Last-Modified",
+	"Server",
+	"Transfer-Encoding",
+	"Vary",
+	"X-Amz-Bucket-Region",
+	"X-Amz-Id-2",
+	"X-Amz-Request-Id",
+	"X-Xss-Protection",
+}
+
+// headers2ObjectInfo converts the response headers of a HEAD call to
+// the object metadata minio-go would return.
+func headers2ObjectInfo(key string, h http.Header) minio.ObjectInfo {
+	objectStat := minio.ObjectInfo{
+		Key:         key,
+		ETag:        strings.Trim(h.Get("ETag"), "\""),
+		ContentType: h.Get("Content-Type"),
+		Metadata:    http.Header{},
+	}
+	objectStat.Size, _ = strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+	objectStat.LastModified, _ = http.ParseTime(h.Get("Last-Modified"))
+	objectStat.Expires, _ = http.ParseTime(h.Get("Expires"))
+	for k, v := range h {
+		isMetadata := true
+		for _, header := range nonMetadataHeaders {
+			if strings.EqualFold(k, header) {
+				isMetadata = false
+				break
+			}
+		}
+		if isMetadata {
+			objectStat.Metadata[k] = v
+		}
+	}
+	return objectStat
+}
+
+// ListVersions - list all versions and delete markers of objects,
+// at delimited path if not recursive.
+func (c *s3Client) ListVersions(isRecursive bool) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	go c.listVersionsInRoutine(isRecursive, contentCh)
+	return contentCh
+}
+
+func (c *s3Client) listVersionsInRoutine(isRecursive bool, contentCh chan *clientContent) {
+	defer close(contentCh)
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		contentCh <- &clientContent{Err: probe.NewError(BucketNameEmpty{})}
+		return
+	}
+
+	query := url.Values{}
+	query.Set("versions", "")
+	query.Set("prefix", object)
+	if !isRecursive {
+		query.Set("delimiter", string(c.targetURL.Separator))
+	}
+	for {
+		result := listVersionsResult{}
+		err := c.executeRequestXML(s3Request{
+			method: http.MethodGet,
+			bucket: bucket,
+			query:  query,
+		}, &result)
+		if err != nil {
+			contentCh <- &clientContent{Err: err.Trace(bucket, object)}
+			return
+		}
+
+		for _, prefix := range result.CommonPrefixes {
+			// Avoid sending the directory we are listing.
+			if prefix.Prefix == object {
+				continue
+			}
+			url := *c.targetURL
+			url.Path = c.joinPath(bucket, prefix.Prefix)
+			contentCh <- &clientContent{URL: url, Time: time.Now(), Type: os.ModeDir}
+		}
+		for _, version := range result.Entries {
+			content := &clientContent{}
+			switch version.XMLName.Local {
+			case "Version":
+				content.Size = version.Size
+				content.ETag = version.ETag
+				content.StorageClass = version.StorageClass
+			case "DeleteMarker":
+				content.IsDeleteMarker = true
+			default:
+				continue
+			}
+			url := *c.targetURL
+			url.Path = c.joinPath(bucket, version.Key)
+			content.URL = url
+			content.Time = version.LastModified
+			content.Type = os.FileMode(0664)
+			content.VersionID = version.VersionID
+			content.IsLatest = version.IsLatest
+			contentCh <- content
+		}
+
+		if !result.IsTruncated {
+			return
+		}
+		query.Set("key-marker", result.NextKeyMarker)
+		query.Set("version-id-marker", result.NextVersionIDMarker)
+	}
+}
+
+// StatVersion - send a 'HEAD' on a version of an object to fetch its metadata.
+func (c *s3Client) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	// Only keys given by the client are needed to read an object.
+	header := minio.GetObjectOptions{ServerSideEncryption: sse}.Header()
+	resp, err := c.executeRequest(s3Request{
+		method: http.MethodHead,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+		header: header,
+	})
+	if err != nil {
+		if resp := minio.ToErrorResponse(err.ToGoError()); resp.Code == "MethodNotAllowed" {
+			// HEAD on a delete marker is not allowed.
+			return nil, probe.NewError(ObjectMissing{})
+		}
+		return nil, c.statError(bucket, err.ToGoError()).Trace(bucket, object, versionID)
+	}
+	resp.Body.Close()
+
+	content := c.objectStat2ClientContent(headers2ObjectInfo(object, resp.Header))
+	content.VersionID = versionID
+	return content, nil
+}
+
+// GetVersion - get a reader on a version of an object.
+func (c *s3Client) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	// Only keys given by the client are needed to read an object.
+	header := minio.GetObjectOptions{ServerSideEncryption: sse}.Header()
+	resp, err := c.executeRequest(s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+		header: header,
+	})
+	if err != nil {
+		errResponse := minio.ToErrorResponse(err.ToGoError())
+		if errResponse.Code == "NoSuchKey" || errResponse.Code == "NoSuchVersion" {
+			return nil, probe.NewError(ObjectMissing{}).Trace(bucket, object, versionID)
+		}
+		return nil, err.Trace(bucket, object, versionID)
+	}
+	return resp.Body, nil
+}
+
+// RemoveVersion - permanently remove a version or a delete marker of an object.
+func (c *s3Client) RemoveVersion(versionID string) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	return c.executeRequestXML(s3Request{
+		method: http.MethodDelete,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+	}, nil)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"encoding/xml"
 	"hash/fnv"
 	"io"
 	"net"
@@ -50,6 +51,11 @@ type s3Client struct {
 	targetURL    *clientURL
 	api          *minio.Client
 	virtualStyle bool
+
+	// Used by requests to APIs not supported by minio-go.
+	endpointURL *url.URL
+	config      *Config
+	transport   http.RoundTripper
 }
 
 const (
@@ -83,6 +89,7 @@ var cseHeaders = []string{
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	transportCache := make(map[uint32]http.RoundTripper)
 	mutex := &sync.Mutex{}
 
 	// Return New function.
@@ -211,11 +218,20 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Cache the new MinIO Client with hash of config as key.
 			clientCache[confSum] = api
+			transportCache[confSum] = transport
 		}
 
 		// Store the new api object.
 		s3Clnt.api = api
 
+		// Save what is needed to sign requests not supported by minio-go.
+		s3Clnt.endpointURL = &url.URL{Scheme: "https", Host: hostName}
+		if !useTLS {
+			s3Clnt.endpointURL.Scheme = "http"
+		}
+		s3Clnt.config = config
+		s3Clnt.transport = transportCache[confSum]
+
 		return s3Clnt, nil
 	}
 }
@@ -415,6 +431,49 @@ func (c *s3Client) ListNotificationConfigs(arn string) ([]notificationConfig, *p
 	return configs, nil
 }
 
+// GetLifecycle - get the lifecycle configuration of the bucket, it
+// has no rules if none is set.
+func (c *s3Client) GetLifecycle() (*lifecycleConfiguration, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	lifecycleXML, e := c.api.GetBucketLifecycle(bucket)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(bucket)
+	}
+	if lifecycleXML == "" {
+		return &lifecycleConfiguration{Rules: []lifecycleRule{}}, nil
+	}
+	lifecycle := &lifecycleConfiguration{}
+	if e = xml.Unmarshal([]byte(lifecycleXML), lifecycle); e != nil {
+		return nil, probe.NewError(e).Trace(bucket)
+	}
+	lifecycle.normalize()
+	return lifecycle, nil
+}
+
+// SetLifecycle - set the lifecycle configuration of the bucket, it is
+// removed if there are no rules.
+func (c *s3Client) SetLifecycle(lifecycle *lifecycleConfiguration) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	lifecycleXML := ""
+	if lifecycle != nil && len(lifecycle.Rules) > 0 {
+		lifecycleBytes, e := xml.Marshal(lifecycle)
+		if e != nil {
+			return probe.NewError(e).Trace(bucket)
+		}
+		lifecycleXML = string(lifecycleBytes)
+	}
+	if e := c.api.SetBucketLifecycle(bucket, lifecycleXML); e != nil {
+		return probe.NewError(e).Trace(bucket)
+	}
+	return nil
+}
+
 // Supported content types
 var supportedContentTypes = []string{
 	"csv",
@@ -423,6 +482,21 @@ var supportedContentTypes = []string{
 	"bzip2",
 }
 
+// isSelectableObject returns true if the object is in a format supported
+// by select, either explicitly requested or guessed from its name.
+func isSelectableObject(object string, selOpts SelectObjectOpts) bool {
+	if _, ok := selOpts.InputSerOpts["parquet"]; ok || strings.Contains(object, ".parquet") {
+		return true
+	}
+	contentType := mimedb.TypeByExtension(filepath.Ext(object))
+	for _, cTypeSuffix := range supportedContentTypes {
+		if strings.Contains(contentType, cTypeSuffix) {
+			return true
+		}
+	}
+	return false
+}
+
 // set the SelectObjectOutputSerialization struct using options passed in by client. If unspecified,
 // default S3 API specified defaults
 func selectObjectOutputOpts(selOpts SelectObjectOpts, i minio.SelectObjectInputSerialization) minio.SelectObjectOutputSerialization {
@@ -526,6 +600,11 @@ func selectObjectInputOpts(selOpts SelectObjectOpts, object string) minio.Select
 		}
 	}
 	i.CompressionType = selectCompressionType(selOpts, object)
+	if i.Parquet != nil {
+		// parquet objects are compressed internally, S3 Select
+		// rejects any other compression type.
+		i.CompressionType = minio.SelectCompressionNONE
+	}
 	return i
 }
 
@@ -718,6 +797,10 @@ func (c *s3Client) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
 	return reader, nil
 }
 
+// Maximum size of an object copied with a single CopyObject request,
+// larger objects are copied in parts with UploadPartCopy.
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
 // Copy - copy object, uses server side copy API. Also uses an abstracted API
 // such that large file sizes will be copied in multipart manner on server
 // side.
@@ -729,16 +812,33 @@ func (c *s3Client) Copy(source string, size int64, progress io.Reader, srcSSE, t
 
 	tokens := splitStr(source, string(c.targetURL.Separator), 3)
 
+	// Only SSE-C keys are needed to read the source object, the
+	// encryption headers of other types would apply to the target.
+	if srcSSE != nil && srcSSE.Type() != encrypt.SSEC {
+		srcSSE = nil
+	}
+
 	// Source object
 	src := minio.NewSourceInfo(tokens[1], tokens[2], srcSSE)
 
+	// Tags are sent along with the copy request.
+	tagsStr, ok := metadata[tagsMetadataKey]
+	if ok {
+		delete(metadata, tagsMetadataKey)
+	}
+
 	// Destination object
 	dst, e := minio.NewDestinationInfo(dstBucket, dstObject, tgtSSE, metadata)
 	if e != nil {
 		return probe.NewError(e)
 	}
 
-	if e = c.api.ComposeObjectWithProgress(dst, []minio.SourceInfo{src}, progress); e != nil {
+	if tagsStr != "" || size > maxCopyObjectSize {
+		e = c.copyObject(tokens[1], tokens[2], dstBucket, dstObject, size, tagsStr, metadata, progress, srcSSE, tgtSSE)
+	} else {
+		e = c.api.ComposeObjectWithProgress(dst, []minio.SourceInfo{src}, progress)
+	}
+	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "AccessDenied" {
 			return probe.NewError(PathInsufficientPermission{
@@ -798,6 +898,12 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 	if ok {
 		delete(metadata, "X-Amz-Storage-Class")
 	}
+
+	// Tags are sent along with the upload request.
+	tagsStr, ok := metadata[tagsMetadataKey]
+	if ok {
+		delete(metadata, tagsMetadataKey)
+	}
 	if bucket == "" {
 		return 0, probe.NewError(BucketNameEmpty{})
 	}
@@ -813,7 +919,13 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 		StorageClass:         strings.ToUpper(storageClass),
 		ServerSideEncryption: sse,
 	}
-	n, e := c.api.PutObjectWithContext(ctx, bucket, object, reader, size, opts)
+	var n int64
+	var e error
+	if tagsStr != "" {
+		n, e = c.putTagged(bucket, object, reader, size, tagsStr, opts)
+	} else {
+		n, e = c.api.PutObjectWithContext(ctx, bucket, object, reader, size, opts)
+	}
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
@@ -910,7 +1022,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					close(objectsCh)
 				}
 				for removeStatus := range statusCh {
-					errorCh <- probe.NewError(removeStatus.Err)
+					errorCh <- c.removeError(prevBucket, removeStatus)
 				}
 				// Remove bucket if it qualifies.
 				if isRemoveBucket && !isIncomplete {
@@ -938,7 +1050,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					case objectsCh <- objectName:
 						sent = true
 					case removeStatus := <-statusCh:
-						errorCh <- probe.NewError(removeStatus.Err)
+						errorCh <- c.removeError(bucket, removeStatus)
 					}
 				}
 			} else {
@@ -956,7 +1068,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 		// Write remove objects status to errorCh
 		if statusCh != nil {
 			for removeStatus := range statusCh {
-				errorCh <- probe.NewError(removeStatus.Err)
+				errorCh <- c.removeError(prevBucket, removeStatus)
 			}
 		}
 		// Remove last bucket if it qualifies.
@@ -969,8 +1081,17 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 	return errorCh
 }
 
+// removeError converts the error of an object removal, it carries the
+// path of the object when it is known.
+func (c *s3Client) removeError(bucket string, removeStatus minio.RemoveObjectError) *probe.Error {
+	if removeStatus.ObjectName == "" {
+		return probe.NewError(removeStatus.Err)
+	}
+	return probe.NewError(ObjectRemoveFailed{Path: c.joinPath(bucket, removeStatus.ObjectName), Err: removeStatus.Err})
+}
+
 // MakeBucket - make a new bucket.
-func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+func (c *s3Client) MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error {
 	bucket, object := c.url2BucketAndObject()
 	if bucket == "" {
 		return probe.NewError(BucketNameEmpty{})
@@ -981,9 +1102,8 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 			if _, e := c.api.PutObject(bucket, object, bytes.NewReader([]byte("")), 0, minio.PutObjectOptions{}); e != nil {
 				switch minio.ToErrorResponse(e).Code {
 				case "NoSuchBucket":
-					e = c.api.MakeBucket(bucket, region)
-					if e != nil {
-						return probe.NewError(e)
+					if err := c.makeBucket(bucket, region, withLock); err != nil {
+						return err
 					}
 					goto retry
 				}
@@ -994,17 +1114,28 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 		return probe.NewError(BucketNameTopLevel{})
 	}
 
-	e := c.api.MakeBucket(bucket, region)
-	if e != nil {
+	err := c.makeBucket(bucket, region, withLock)
+	if err != nil {
 		// Ignore bucket already existing error when ignoreExisting flag is enabled
 		if ignoreExisting {
-			switch minio.ToErrorResponse(e).Code {
+			switch minio.ToErrorResponse(err.ToGoError()).Code {
 			case "BucketAlreadyOwnedByYou":
 				fallthrough
 			case "BucketAlreadyExists":
 				return nil
 			}
 		}
+		return err
+	}
+	return nil
+}
+
+// makeBucket - create a bucket, with object lock enabled if withLock is set.
+func (c *s3Client) makeBucket(bucket, region string, withLock bool) *probe.Error {
+	if withLock {
+		return c.makeBucketWithLock(bucket, region)
+	}
+	if e := c.api.MakeBucket(bucket, region); e != nil {
 		return probe.NewError(e)
 	}
 	return nil
@@ -1185,6 +1316,7 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 					return nil, err
 				}
 				objectMetadata.ETag = stat.ETag
+				objectMetadata.StorageClass = stat.StorageClass
 				objectMetadata.Metadata = stat.Metadata
 				objectMetadata.EncryptionHeaders = stat.EncryptionHeaders
 				objectMetadata.Expires = stat.Expires
@@ -1195,6 +1327,7 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 			objectMetadata.Time = objectStat.LastModified
 			objectMetadata.Size = objectStat.Size
 			objectMetadata.ETag = objectStat.ETag
+			objectMetadata.StorageClass = objectStat.StorageClass
 			objectMetadata.Type = os.FileMode(0664)
 			objectMetadata.Metadata = map[string]string{}
 			objectMetadata.Expires = objectStat.Expires
@@ -1207,6 +1340,9 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 				objectMetadata.Metadata = stat.Metadata
 				objectMetadata.EncryptionHeaders = stat.EncryptionHeaders
 				objectMetadata.Expires = stat.Expires
+				objectMetadata.RetentionMode = stat.RetentionMode
+				objectMetadata.RetainUntilDate = stat.RetainUntilDate
+				objectMetadata.LegalHold = stat.LegalHold
 			}
 			return objectMetadata, nil
 		}
@@ -1216,42 +1352,55 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 
 // getObjectStat returns the metadata of an object from a HEAD call.
 func (c *s3Client) getObjectStat(bucket, object string, opts minio.StatObjectOptions) (*clientContent, *probe.Error) {
-	objectMetadata := &clientContent{}
 	objectStat, e := c.api.StatObject(bucket, object, opts)
 	if e != nil {
-		errResponse := minio.ToErrorResponse(e)
-		if errResponse.Code == "AccessDenied" {
-			return nil, probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
-		}
-		if errResponse.Code == "NoSuchBucket" {
-			return nil, probe.NewError(BucketDoesNotExist{
-				Bucket: bucket,
-			})
-		}
-		if errResponse.Code == "InvalidBucketName" {
-			return nil, probe.NewError(BucketInvalid{
-				Bucket: bucket,
-			})
-		}
-		if errResponse.Code == "NoSuchKey" {
-			return nil, probe.NewError(ObjectMissing{})
-		}
-		return nil, probe.NewError(e)
+		return nil, c.statError(bucket, e)
 	}
+	return c.objectStat2ClientContent(objectStat), nil
+}
+
+// statError converts an error of a HEAD call to the errors of mc.
+func (c *s3Client) statError(bucket string, e error) *probe.Error {
+	errResponse := minio.ToErrorResponse(e)
+	if errResponse.Code == "AccessDenied" {
+		return probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+	}
+	if errResponse.Code == "NoSuchBucket" {
+		return probe.NewError(BucketDoesNotExist{
+			Bucket: bucket,
+		})
+	}
+	if errResponse.Code == "InvalidBucketName" {
+		return probe.NewError(BucketInvalid{
+			Bucket: bucket,
+		})
+	}
+	if errResponse.Code == "NoSuchKey" {
+		return probe.NewError(ObjectMissing{})
+	}
+	return probe.NewError(e)
+}
+
+// objectStat2ClientContent converts the metadata of an object, as
+// returned by a HEAD call, to clientContent.
+func (c *s3Client) objectStat2ClientContent(objectStat minio.ObjectInfo) *clientContent {
+	objectMetadata := &clientContent{}
 	objectMetadata.URL = *c.targetURL
 	objectMetadata.Time = objectStat.LastModified
 	objectMetadata.Size = objectStat.Size
 	objectMetadata.ETag = objectStat.ETag
+	objectMetadata.StorageClass = objectStat.StorageClass
 	objectMetadata.Expires = objectStat.Expires
 	objectMetadata.Type = os.FileMode(0664)
 	objectMetadata.Metadata = map[string]string{}
 	objectMetadata.EncryptionHeaders = map[string]string{}
 	objectMetadata.Metadata["Content-Type"] = objectStat.ContentType
+	objectMetadata.VersionID = objectStat.Metadata.Get("X-Amz-Version-Id")
 	for k, v := range objectStat.Metadata {
 		isCSEHeader := false
 		for _, header := range cseHeaders {
 			if (strings.Compare(strings.ToLower(header), strings.ToLower(k)) == 0) ||
-				strings.HasPrefix(strings.ToLower(serverEncryptionKeyPrefix), strings.ToLower(k)) {
+				strings.HasPrefix(strings.ToLower(k), strings.ToLower(serverEncryptionKeyPrefix)) {
 				if len(v) > 0 {
 					objectMetadata.EncryptionHeaders[k] = v[0]
 				}
@@ -1265,8 +1414,17 @@ func (c *s3Client) getObjectStat(bucket, object string, opts minio.StatObjectOpt
 			}
 		}
 	}
+	// Object lock headers are shown on their own.
+	objectMetadata.RetentionMode = objectStat.Metadata.Get(objectLockModeHeader)
+	if until := objectStat.Metadata.Get(objectLockRetainUntilDateHeader); until != "" {
+		objectMetadata.RetainUntilDate, _ = time.Parse(time.RFC3339, until)
+	}
+	objectMetadata.LegalHold = objectStat.Metadata.Get(objectLockLegalHoldHeader)
+	delete(objectMetadata.Metadata, objectLockModeHeader)
+	delete(objectMetadata.Metadata, objectLockRetainUntilDateHeader)
+	delete(objectMetadata.Metadata, objectLockLegalHoldHeader)
 	objectMetadata.ETag = objectStat.ETag
-	return objectMetadata, nil
+	return objectMetadata
 }
 
 func isAmazon(host string) bool {
@@ -1647,6 +1805,7 @@ func (c *s3Client) objectInfo2ClientContent(bucket string, entry minio.ObjectInf
 	content.URL = url
 	content.Size = entry.Size
 	content.ETag = entry.ETag
+	content.StorageClass = entry.StorageClass
 	content.Time = entry.LastModified
 
 	if strings.HasSuffix(entry.Key, "/") && entry.Size == 0 && entry.LastModified.IsZero() {
@@ -1827,6 +1986,7 @@ func (c *s3Client) listInRoutine(contentCh chan *clientContent) {
 				content.URL = url
 				content.Size = object.Size
 				content.ETag = object.ETag
+				content.StorageClass = object.StorageClass
 				content.Time = object.LastModified
 				content.Type = os.FileMode(0664)
 			}
@@ -1883,6 +2043,7 @@ func (c *s3Client) listRecursiveInRoutine(contentCh chan *clientContent) {
 				content.URL = objectURL
 				content.Size = object.Size
 				content.ETag = object.ETag
+				content.StorageClass = object.StorageClass
 				content.Time = object.LastModified
 				content.Type = os.FileMode(0664)
 				contentCh <- content
@@ -1904,6 +2065,7 @@ func (c *s3Client) listRecursiveInRoutine(contentCh chan *clientContent) {
 			content.URL = url
 			content.Size = object.Size
 			content.ETag = object.ETag
+			content.StorageClass = object.StorageClass
 			content.Time = object.LastModified
 			content.Type = os.FileMode(0664)
 			contentCh <- content
@@ -20,12 +20,21 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
 	. "gopkg.in/check.v1"
 )
 
@@ -171,7 +180,7 @@ func (s *TestSuite) TestBucketOperations(c *C) {
 	s3c, err := s3New(conf)
 	c.Assert(err, IsNil)
 
-	err = s3c.MakeBucket("us-east-1", true)
+	err = s3c.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 
 	conf.HostURL = server.URL + string(s3c.GetURL().Separator)
@@ -237,6 +246,316 @@ func (s *TestSuite) TestObjectOperations(c *C) {
 	}
 }
 
+// versionHandler is an http.Handler that serves the versions of a single object.
+type versionHandler struct {
+	resource string
+	data     []byte
+}
+
+func (h versionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if r.Header.Get("X-Amz-Server-Side-Encryption") != "" {
+		// Only keys given by the client may be sent to read objects.
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch {
+	case r.Method == "GET":
+		if _, ok := query["location"]; ok {
+			response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+			w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+			w.Write(response)
+			return
+		}
+		// Handler for ListObjectVersions request.
+		if _, ok := query["versions"]; ok && r.URL.Path == "/bucket/" {
+			response := []byte("<ListVersionsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Name>bucket</Name><Prefix></Prefix><KeyMarker></KeyMarker><VersionIdMarker></VersionIdMarker><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated><DeleteMarker><Key>object</Key><VersionId>v3</VersionId><IsLatest>true</IsLatest><LastModified>2019-05-21T18:24:21.097Z</LastModified></DeleteMarker><Version><Key>object</Key><VersionId>v2</VersionId><IsLatest>false</IsLatest><LastModified>2019-05-20T18:24:21.097Z</LastModified><ETag>&quot;259d04a13802ae09c7e41be50ccc6baa&quot;</ETag><Size>12</Size><StorageClass>STANDARD</StorageClass></Version><Version><Key>object</Key><VersionId>v1</VersionId><IsLatest>false</IsLatest><LastModified>2019-05-19T18:24:21.097Z</LastModified><ETag>&quot;9af2f8218b150c351ad802c6f3d66abe&quot;</ETag><Size>5</Size><StorageClass>STANDARD</StorageClass></Version></ListVersionsResult>")
+			w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+			w.Write(response)
+			return
+		}
+		if r.URL.Path != h.resource || query.Get("versionId") != "v2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(h.data)
+	case r.Method == "HEAD":
+		if r.URL.Path != h.resource {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch query.Get("versionId") {
+		case "v2":
+			w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
+			w.Header().Set("Last-Modified", "Mon, 20 May 2019 18:24:21 GMT")
+			w.Header().Set("ETag", "\"259d04a13802ae09c7e41be50ccc6baa\"")
+			w.Header().Set("X-Amz-Version-Id", "v2")
+			w.Header().Set("X-Amz-Meta-Owner", "minio")
+			w.WriteHeader(http.StatusOK)
+		case "v3":
+			// Delete markers cannot be stat'ed.
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == "DELETE":
+		if r.URL.Path != h.resource || query.Get("versionId") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test object version operations.
+func (s *TestSuite) TestObjectVersionOperations(c *C) {
+	handler := versionHandler{
+		resource: "/bucket/object",
+		data:     []byte("Hello, World"),
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	var versions []*clientContent
+	for content := range s3c.ListVersions(true) {
+		c.Assert(content.Err, IsNil)
+		versions = append(versions, content)
+	}
+	c.Assert(len(versions), Equals, 3)
+	c.Assert(versions[0].VersionID, Equals, "v3")
+	c.Assert(versions[0].IsDeleteMarker, Equals, true)
+	c.Assert(versions[0].IsLatest, Equals, true)
+	c.Assert(versions[1].VersionID, Equals, "v2")
+	c.Assert(versions[1].Size, Equals, int64(12))
+	c.Assert(versions[1].IsDeleteMarker, Equals, false)
+	c.Assert(versions[2].URL.Path, Equals, "/bucket/object")
+
+	conf.HostURL = server.URL + handler.resource
+	s3c, err = s3New(conf)
+	c.Assert(err, IsNil)
+
+	content, err := s3c.StatVersion("v2", nil)
+	c.Assert(err, IsNil)
+	c.Assert(content.VersionID, Equals, "v2")
+	c.Assert(content.Size, Equals, int64(len(handler.data)))
+	c.Assert(content.ETag, Equals, "259d04a13802ae09c7e41be50ccc6baa")
+	c.Assert(content.Metadata["X-Amz-Meta-Owner"], Equals, "minio")
+
+	_, err = s3c.StatVersion("v3", nil)
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(ObjectMissing)
+	c.Assert(ok, Equals, true)
+
+	reader, err := s3c.GetVersion("v2", nil)
+	c.Assert(err, IsNil)
+	var buffer bytes.Buffer
+	{
+		_, err := io.Copy(&buffer, reader)
+		c.Assert(err, IsNil)
+		c.Assert(buffer.Bytes(), DeepEquals, handler.data)
+	}
+	reader.Close()
+
+	// Server side encryption headers are not sent to read objects.
+	_, err = s3c.StatVersion("v2", encrypt.NewSSE())
+	c.Assert(err, IsNil)
+	reader, err = s3c.GetVersion("v2", encrypt.NewSSE())
+	c.Assert(err, IsNil)
+	reader.Close()
+
+	c.Assert(s3c.RemoveVersion("v1"), IsNil)
+}
+
+// taggingHandler is an http.Handler that stores the tags of objects and buckets.
+type taggingHandler struct {
+	tags map[string][]byte
+}
+
+func (h taggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	if _, ok := query["tagging"]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		data, ok := h.tags[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchTagSet</Code><Message>The TagSet does not exist</Message></Error>"))
+			return
+		}
+		w.Write(data)
+	case "PUT":
+		data, _ := ioutil.ReadAll(r.Body)
+		h.tags[r.URL.Path] = data
+	case "DELETE":
+		delete(h.tags, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test object and bucket tagging operations.
+func (s *TestSuite) TestTaggingOperations(c *C) {
+	handler := taggingHandler{tags: make(map[string][]byte)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	tags, err := parseTags("project=alpha&owner=ops")
+	c.Assert(err, IsNil)
+	c.Assert(s3c.SetTags(tags), IsNil)
+	c.Assert(string(handler.tags["/bucket/object"]), Equals, "<Tagging><TagSet><Tag><Key>owner</Key><Value>ops</Value></Tag><Tag><Key>project</Key><Value>alpha</Value></Tag></TagSet></Tagging>")
+
+	gotTags, err := s3c.GetTags()
+	c.Assert(err, IsNil)
+	c.Assert(gotTags, DeepEquals, tags)
+
+	c.Assert(s3c.SetTags(nil), IsNil)
+	_, ok := handler.tags["/bucket/object"]
+	c.Assert(ok, Equals, false)
+
+	// Buckets without tags have no tags.
+	conf.HostURL = server.URL + "/bucket"
+	s3c, err = s3New(conf)
+	c.Assert(err, IsNil)
+	gotTags, err = s3c.GetTags()
+	c.Assert(err, IsNil)
+	c.Assert(len(gotTags), Equals, 0)
+}
+
+// objectLockHandler is an http.Handler that stores the retention and
+// legal hold of a single object.
+type objectLockHandler struct {
+	resource    string
+	subResource map[string][]byte
+}
+
+func (h objectLockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	if r.Method == "GET" && r.URL.Path == "/bucket/" {
+		response := []byte("<ListBucketResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Name>bucket</Name><Prefix>object</Prefix><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated><Contents><Key>object</Key><LastModified>2019-05-20T18:24:21.000Z</LastModified><Size>0</Size></Contents></ListBucketResult>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	if r.URL.Path != h.resource {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	subResource := "retention"
+	if _, ok := query["legal-hold"]; ok {
+		subResource = "legal-hold"
+	}
+	switch r.Method {
+	case "HEAD":
+		w.Header().Set("Content-Length", "0")
+		w.Header().Set("Last-Modified", "Mon, 20 May 2019 18:24:21 GMT")
+		w.Header().Set("X-Amz-Object-Lock-Mode", "GOVERNANCE")
+		w.Header().Set("X-Amz-Object-Lock-Retain-Until-Date", "2020-01-31T00:00:00Z")
+		w.Header().Set("X-Amz-Object-Lock-Legal-Hold", "ON")
+		w.WriteHeader(http.StatusOK)
+	case "GET":
+		data, ok := h.subResource[subResource]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>"))
+			return
+		}
+		w.Write(data)
+	case "PUT":
+		if subResource == "retention" && r.Header.Get("X-Amz-Bypass-Governance-Retention") != "true" {
+			if _, ok := h.subResource[subResource]; ok {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		h.subResource[subResource] = data
+	}
+}
+
+// Test object retention and legal hold operations.
+func (s *TestSuite) TestObjectLockOperations(c *C) {
+	handler := objectLockHandler{
+		resource:    "/bucket/object",
+		subResource: make(map[string][]byte),
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + handler.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	mode, _, err := s3c.GetRetention()
+	c.Assert(err, IsNil)
+	c.Assert(mode, Equals, "")
+
+	retainUntil := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	c.Assert(s3c.SetRetention(retentionGovernance, retainUntil, false), IsNil)
+	c.Assert(string(handler.subResource["retention"]), Equals, "<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>2020-01-31T00:00:00Z</RetainUntilDate></Retention>")
+	mode, until, err := s3c.GetRetention()
+	c.Assert(err, IsNil)
+	c.Assert(mode, Equals, retentionGovernance)
+	c.Assert(until.Equal(retainUntil), Equals, true)
+
+	// Governance retention is only changed with a bypass.
+	c.Assert(s3c.SetRetention(retentionGovernance, retainUntil.AddDate(0, 0, -1), false), NotNil)
+	c.Assert(s3c.SetRetention("", time.Time{}, true), IsNil)
+	c.Assert(string(handler.subResource["retention"]), Equals, "<Retention></Retention>")
+
+	status, err := s3c.GetLegalHold()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, legalHoldOff)
+	c.Assert(s3c.SetLegalHold(legalHoldOn), IsNil)
+	status, err = s3c.GetLegalHold()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, legalHoldOn)
+
+	content, err := s3c.Stat(false, true, nil)
+	c.Assert(err, IsNil)
+	c.Assert(content.RetentionMode, Equals, retentionGovernance)
+	c.Assert(content.RetainUntilDate.Equal(retainUntil), Equals, true)
+	c.Assert(content.LegalHold, Equals, legalHoldOn)
+	_, ok := content.Metadata["X-Amz-Object-Lock-Mode"]
+	c.Assert(ok, Equals, false)
+}
+
 var testSelectCompressionTypeCases = []struct {
 	opts            SelectObjectOpts
 	object          string
@@ -261,3 +580,545 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+// eventStreamMessage encodes an S3 Select event stream message.
+func eventStreamMessage(headers map[string]string, payload []byte) []byte {
+	var hdr bytes.Buffer
+	for _, name := range []string{":message-type", ":event-type", ":content-type"} {
+		value, ok := headers[name]
+		if !ok {
+			continue
+		}
+		hdr.WriteByte(byte(len(name)))
+		hdr.WriteString(name)
+		hdr.WriteByte(7) // string value
+		binary.Write(&hdr, binary.BigEndian, uint16(len(value)))
+		hdr.WriteString(value)
+	}
+	var msg bytes.Buffer
+	binary.Write(&msg, binary.BigEndian, uint32(hdr.Len()+len(payload)+16))
+	binary.Write(&msg, binary.BigEndian, uint32(hdr.Len()))
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	msg.Write(hdr.Bytes())
+	msg.Write(payload)
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	return msg.Bytes()
+}
+
+// selectHandler is an http.Handler that records select requests and
+// replies with fixed records.
+type selectHandler struct {
+	requests map[string]*minio.SelectObjectOptions
+	records  []byte
+}
+
+func (h selectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	if _, ok := query["select"]; !ok || r.Method != "POST" || query.Get("select-type") != "2" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	opts := &minio.SelectObjectOptions{}
+	if err := xml.NewDecoder(r.Body).Decode(opts); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	h.requests[r.URL.Path] = opts
+	w.Write(eventStreamMessage(map[string]string{":message-type": "event", ":event-type": "Records", ":content-type": "application/octet-stream"}, h.records))
+	w.Write(eventStreamMessage(map[string]string{":message-type": "event", ":event-type": "End"}, nil))
+}
+
+// Test select requests on parquet objects.
+func (s *TestSuite) TestSelectParquet(c *C) {
+	handler := selectHandler{requests: make(map[string]*minio.SelectObjectOptions), records: []byte("1,alice\n2,bob\n")}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCases := []struct {
+		object string
+		opts   SelectObjectOpts
+	}{
+		// Detected from the object name.
+		{"/bucket/sales/2019.parquet", SelectObjectOpts{}},
+		// Requested explicitly, compression is never sent for parquet.
+		{"/bucket/sales/2019", SelectObjectOpts{
+			InputSerOpts:    map[string]map[string]string{"parquet": {}},
+			CompressionType: minio.SelectCompressionGZIP,
+		}},
+	}
+	for _, testCase := range testCases {
+		conf := new(Config)
+		conf.HostURL = server.URL + testCase.object
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		s3c, err := s3New(conf)
+		c.Assert(err, IsNil)
+
+		reader, err := s3c.Select("select * from S3Object", nil, testCase.opts)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		reader.Close()
+		c.Assert(string(data), Equals, "1,alice\n2,bob\n")
+
+		req, ok := handler.requests[testCase.object]
+		c.Assert(ok, Equals, true)
+		c.Assert(req.Expression, Equals, "select * from S3Object")
+		c.Assert(req.InputSerialization.Parquet, NotNil)
+		c.Assert(req.InputSerialization.CSV, IsNil)
+		c.Assert(req.InputSerialization.JSON, IsNil)
+		c.Assert(req.InputSerialization.CompressionType, Equals, minio.SelectCompressionType(minio.SelectCompressionNONE))
+		c.Assert(req.OutputSerialization.CSV, NotNil)
+	}
+
+	c.Assert(isSelectableObject("sales/2019.parquet", SelectObjectOpts{}), Equals, true)
+	c.Assert(isSelectableObject("sales/2019", SelectObjectOpts{}), Equals, false)
+	c.Assert(isSelectableObject("sales/2019", testCases[1].opts), Equals, true)
+	c.Assert(isSelectableObject("sales/2019.csv.gz", SelectObjectOpts{}), Equals, true)
+}
+
+// bucketConfigHandler is an http.Handler that stores the sub-resources
+// of a bucket, missing sub-resources reply with their S3 error code.
+type bucketConfigHandler struct {
+	configs       map[string][]byte
+	notFoundCodes map[string]string
+}
+
+func (h bucketConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	var subResource string
+	for name := range query {
+		subResource = name
+	}
+	switch r.Method {
+	case "GET":
+		data, ok := h.configs[subResource]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>" + h.notFoundCodes[subResource] + "</Code></Error>"))
+			return
+		}
+		w.Write(data)
+	case "PUT":
+		data, _ := ioutil.ReadAll(r.Body)
+		h.configs[subResource] = data
+	case "DELETE":
+		delete(h.configs, subResource)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test reading the configuration of a bucket.
+func (s *TestSuite) TestBucketConfigOperations(c *C) {
+	handler := bucketConfigHandler{
+		configs: map[string][]byte{
+			"versioning": []byte("<VersioningConfiguration></VersioningConfiguration>"),
+		},
+		notFoundCodes: map[string]string{
+			"encryption":  "ServerSideEncryptionConfigurationNotFoundError",
+			"object-lock": "ObjectLockConfigurationNotFoundError",
+			"versioning":  "NoSuchBucket",
+		},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3Clnt := s3c.(*s3Client)
+
+	// Buckets without configuration.
+	status, err := s3Clnt.GetVersioning()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, "")
+	encryption, err := s3Clnt.GetEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(encryption, IsNil)
+	lock, err := s3Clnt.GetObjectLockConfig()
+	c.Assert(err, IsNil)
+	c.Assert(lock, IsNil)
+
+	handler.configs["versioning"] = []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Suspended</Status></VersioningConfiguration>`)
+	handler.configs["encryption"] = []byte(`<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)
+	handler.configs["object-lock"] = []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`)
+
+	status, err = s3Clnt.GetVersioning()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, "Suspended")
+	encryption, err = s3Clnt.GetEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(len(encryption.Rules), Equals, 1)
+	c.Assert(encryption.Rules[0].Apply.SSEAlgorithm, Equals, "aws:kms")
+	c.Assert(encryption.Rules[0].Apply.KMSMasterKeyID, Equals, "key")
+	lock, err = s3Clnt.GetObjectLockConfig()
+	c.Assert(err, IsNil)
+	c.Assert(lock.ObjectLockEnabled, Equals, "Enabled")
+	c.Assert(lock.Rule.DefaultRetention.Mode, Equals, "COMPLIANCE")
+	c.Assert(lock.Rule.DefaultRetention.Years, Equals, 1)
+
+	// Other errors are returned.
+	delete(handler.configs, "versioning")
+	_, err = s3Clnt.GetVersioning()
+	c.Assert(err, NotNil)
+}
+
+// Test enabling and suspending bucket versioning.
+func (s *TestSuite) TestVersioningOperations(c *C) {
+	handler := bucketConfigHandler{configs: make(map[string][]byte)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	c.Assert(s3c.SetVersioning(versioningEnabled), IsNil)
+	c.Assert(string(handler.configs["versioning"]), Equals, "<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>")
+	status, err := s3c.GetVersioning()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, versioningEnabled)
+
+	c.Assert(s3c.SetVersioning(versioningSuspended), IsNil)
+	status, err = s3c.GetVersioning()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, versioningSuspended)
+
+	// Versioning is not supported by filesystems.
+	fsClnt, err := fsNew(c.MkDir())
+	c.Assert(err, IsNil)
+	_, err = fsClnt.GetVersioning()
+	c.Assert(err, NotNil)
+	c.Assert(fsClnt.SetVersioning(versioningEnabled), NotNil)
+}
+
+// Test setting and removing the replication configuration of a bucket.
+func (s *TestSuite) TestReplicationOperations(c *C) {
+	handler := bucketConfigHandler{
+		configs:       make(map[string][]byte),
+		notFoundCodes: map[string]string{"replication": "ReplicationConfigurationNotFoundError"},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	// No replication configuration is set yet.
+	replication, err := s3c.GetReplication()
+	c.Assert(err, IsNil)
+	c.Assert(len(replication.Rules), Equals, 0)
+
+	rule, err := replicateOptions{ID: "backup", Prefix: "docs/", ARN: "arn:aws:s3:::backup", Priority: 1, ReplicateDeleteMarkers: true}.toRule()
+	c.Assert(err, IsNil)
+	replication.addRule(rule)
+	c.Assert(s3c.SetReplication(replication), IsNil)
+
+	replication, err = s3c.GetReplication()
+	c.Assert(err, IsNil)
+	c.Assert(len(replication.Rules), Equals, 1)
+	c.Assert(replication.Rules[0].ID, Equals, "backup")
+	c.Assert(replication.Rules[0].prefix(), Equals, "docs/")
+	c.Assert(replication.Rules[0].Priority, Equals, 1)
+	c.Assert(replication.Rules[0].DeleteMarkerReplication.Status, Equals, replicationStatusEnabled)
+	c.Assert(replication.Rules[0].Destination.Bucket, Equals, "arn:aws:s3:::backup")
+
+	// Removing the last rule removes the configuration.
+	replication.removeRule("backup")
+	c.Assert(s3c.SetReplication(replication), IsNil)
+	_, ok := handler.configs["replication"]
+	c.Assert(ok, Equals, false)
+	replication, err = s3c.GetReplication()
+	c.Assert(err, IsNil)
+	c.Assert(len(replication.Rules), Equals, 0)
+}
+
+// Test setting and removing the default encryption of a bucket.
+func (s *TestSuite) TestEncryptionOperations(c *C) {
+	handler := bucketConfigHandler{
+		configs:       make(map[string][]byte),
+		notFoundCodes: map[string]string{"encryption": "ServerSideEncryptionConfigurationNotFoundError"},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	c.Assert(s3c.SetEncryption(encryptionSSEKMS, "my-key"), IsNil)
+	config, err := s3c.GetEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(config, NotNil)
+	c.Assert(len(config.Rules), Equals, 1)
+	c.Assert(config.Rules[0].Apply.SSEAlgorithm, Equals, encryptionSSEKMS)
+	c.Assert(config.Rules[0].Apply.KMSMasterKeyID, Equals, "my-key")
+
+	c.Assert(s3c.DeleteEncryption(), IsNil)
+	config, err = s3c.GetEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(config, IsNil)
+}
+
+// Test setting and removing the CORS configuration of a bucket.
+func (s *TestSuite) TestCORSOperations(c *C) {
+	handler := bucketConfigHandler{
+		configs:       make(map[string][]byte),
+		notFoundCodes: map[string]string{"cors": "NoSuchCORSConfiguration"},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	cors, err := s3c.GetCORS()
+	c.Assert(err, IsNil)
+	c.Assert(cors, IsNil)
+
+	rule := corsRule{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "HEAD"}, MaxAgeSeconds: 600}
+	c.Assert(s3c.SetCORS(&corsConfiguration{Rules: []corsRule{rule}}), IsNil)
+	c.Assert(string(handler.configs["cors"]), Equals, "<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin>"+
+		"<AllowedMethod>GET</AllowedMethod><AllowedMethod>HEAD</AllowedMethod><MaxAgeSeconds>600</MaxAgeSeconds></CORSRule></CORSConfiguration>")
+	cors, err = s3c.GetCORS()
+	c.Assert(err, IsNil)
+	c.Assert(cors, NotNil)
+	c.Assert(cors.Rules, DeepEquals, []corsRule{rule})
+
+	c.Assert(s3c.DeleteCORS(), IsNil)
+	cors, err = s3c.GetCORS()
+	c.Assert(err, IsNil)
+	c.Assert(cors, IsNil)
+}
+
+// partCopyHandler is an http.Handler that copies a large object in
+// parts, the copy of part failPart is denied. Another client has an
+// upload of the same object in progress.
+type partCopyHandler struct {
+	sync.Mutex
+	size         int64
+	failPart     string
+	failAbort    bool
+	ranges       []string
+	tagging      string
+	completed    bool
+	aborted      bool
+	abortedOther bool
+}
+
+func (h *partCopyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	query := r.URL.Query()
+	switch {
+	case r.Method == "GET" && len(query["location"]) > 0:
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+	case r.Method == "HEAD" && r.URL.Path == "/bucket/source":
+		w.Header().Set("Content-Length", strconv.FormatInt(h.size, 10))
+		w.Header().Set("Last-Modified", UTCNow().Format(http.TimeFormat))
+		w.Header().Set("ETag", "\"9af2f8218b150c351ad802c6f3d66abe\"")
+	case r.Method == "POST" && len(query["uploads"]) > 0:
+		h.tagging = r.Header.Get("X-Amz-Tagging")
+		w.Write([]byte("<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>target</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>"))
+	case r.Method == "PUT" && query.Get("uploadId") == "upload":
+		if query.Get("partNumber") == h.failPart {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+			return
+		}
+		h.ranges = append(h.ranges, r.Header.Get("X-Amz-Copy-Source-Range"))
+		w.Write([]byte("<CopyPartResult><ETag>\"etag\"</ETag><LastModified>2019-10-01T00:00:00.000Z</LastModified></CopyPartResult>"))
+	case r.Method == "POST" && query.Get("uploadId") == "upload":
+		h.completed = true
+		w.Write([]byte("<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>target</Key><ETag>\"etag-2\"</ETag></CompleteMultipartUploadResult>"))
+	case r.Method == "GET" && len(query["uploads"]) > 0:
+		response := "<ListMultipartUploadsResult><Bucket>bucket</Bucket><IsTruncated>false</IsTruncated>"
+		if !h.completed && !h.aborted {
+			response += "<Upload><Key>target</Key><UploadId>upload</UploadId></Upload>"
+		}
+		if !h.abortedOther {
+			response += "<Upload><Key>target</Key><UploadId>other</UploadId></Upload>"
+		}
+		w.Write([]byte(response + "</ListMultipartUploadsResult>"))
+	case r.Method == "DELETE" && query.Get("uploadId") == "upload":
+		if h.failAbort {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+			return
+		}
+		h.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "DELETE" && query.Get("uploadId") == "other":
+		h.abortedOther = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Test server side copy of objects larger than 5 GiB.
+func (s *TestSuite) TestLargeObjectCopy(c *C) {
+	size := int64(6 * 1024 * 1024 * 1024)
+	handler := &partCopyHandler{size: size}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/target"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	c.Assert(s3c.Copy("/bucket/source", size, nil, nil, nil, map[string]string{}), IsNil)
+	c.Assert(handler.completed, Equals, true)
+	c.Assert(len(handler.ranges) > 1, Equals, true)
+	// Parts are contiguous and cover the whole object.
+	var next int64
+	for _, r := range handler.ranges {
+		var start, end int64
+		_, e := fmt.Sscanf(r, "bytes=%d-%d", &start, &end)
+		c.Assert(e, IsNil)
+		c.Assert(start, Equals, next)
+		next = end + 1
+	}
+	c.Assert(next, Equals, size)
+
+	// Only the multipart upload of a failed copy is aborted.
+	handler = &partCopyHandler{size: size, failPart: "2"}
+	server.Config.Handler = handler
+	err = s3c.Copy("/bucket/source", size, nil, nil, nil, map[string]string{})
+	c.Assert(err, NotNil)
+	c.Assert(handler.completed, Equals, false)
+	c.Assert(handler.aborted, Equals, true)
+	c.Assert(handler.abortedOther, Equals, false)
+
+	// An upload which cannot be aborted is reported.
+	handler = &partCopyHandler{size: size, failPart: "2", failAbort: true}
+	server.Config.Handler = handler
+	err = s3c.Copy("/bucket/source", size, nil, nil, nil, map[string]string{})
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.ToGoError().Error(), "unable to abort the incomplete upload `upload`"), Equals, true)
+	c.Assert(handler.abortedOther, Equals, false)
+
+	// Tags are set when the multipart upload is initiated.
+	handler = &partCopyHandler{size: size}
+	server.Config.Handler = handler
+	c.Assert(s3c.Copy("/bucket/source", size, nil, nil, nil, map[string]string{tagsMetadataKey: "owner=ops"}), IsNil)
+	c.Assert(handler.tagging, Equals, "owner=ops")
+	c.Assert(handler.completed, Equals, true)
+}
+
+// taggedUploadHandler is an http.Handler that records the tags sent
+// with uploads, copies and initiated multipart uploads.
+type taggedUploadHandler struct {
+	sync.Mutex
+	tags      map[string]string
+	parts     int
+	completed bool
+}
+
+func (h *taggedUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	query := r.URL.Query()
+	switch {
+	case r.Method == "GET" && len(query["location"]) > 0:
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+	case len(query["tagging"]) > 0:
+		// Tags must not be set by a separate request.
+		w.WriteHeader(http.StatusBadRequest)
+	case r.Method == "POST" && len(query["uploads"]) > 0:
+		h.tags["initiate"] = r.Header.Get("X-Amz-Tagging")
+		w.Write([]byte("<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>"))
+	case r.Method == "PUT" && query.Get("uploadId") == "upload":
+		io.Copy(ioutil.Discard, r.Body)
+		h.parts++
+		w.Header().Set("ETag", "\"etag\"")
+	case r.Method == "POST" && query.Get("uploadId") == "upload":
+		h.completed = true
+		w.Write([]byte("<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>\"etag-2\"</ETag></CompleteMultipartUploadResult>"))
+	case r.Method == "PUT" && r.Header.Get("X-Amz-Copy-Source") != "":
+		h.tags["copy"] = r.Header.Get("X-Amz-Tagging") + " " + r.Header.Get("X-Amz-Tagging-Directive")
+		w.Write([]byte("<CopyObjectResult><ETag>\"etag\"</ETag><LastModified>2019-10-01T00:00:00.000Z</LastModified></CopyObjectResult>"))
+	case r.Method == "PUT":
+		io.Copy(ioutil.Discard, r.Body)
+		h.tags["put"] = r.Header.Get("X-Amz-Tagging")
+		w.Header().Set("ETag", "\"etag\"")
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Test that tags are sent along with uploads and copies.
+func (s *TestSuite) TestTaggedUploads(c *C) {
+	handler := &taggedUploadHandler{tags: make(map[string]string)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	const tagsStr = "project=alpha beta&owner=ops"
+	data := []byte("hello world")
+	n, err := s3c.Put(context.Background(), bytes.NewReader(data), int64(len(data)), map[string]string{tagsMetadataKey: tagsStr}, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(data)))
+	c.Assert(handler.tags["put"], Equals, "owner=ops&project=alpha%20beta")
+
+	// Objects larger than a part are uploaded in parts.
+	size := int64(taggedUploadPartSize + 1)
+	n, err = s3c.Put(context.Background(), bytes.NewReader(make([]byte, size)), -1, map[string]string{tagsMetadataKey: tagsStr}, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, size)
+	c.Assert(handler.tags["initiate"], Equals, "owner=ops&project=alpha%20beta")
+	c.Assert(handler.parts, Equals, 2)
+	c.Assert(handler.completed, Equals, true)
+
+	c.Assert(s3c.Copy("/bucket/source", int64(len(data)), nil, nil, nil, map[string]string{tagsMetadataKey: tagsStr}), IsNil)
+	c.Assert(handler.tags["copy"], Equals, "owner=ops&project=alpha%20beta REPLACE")
+}
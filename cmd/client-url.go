@@ -186,6 +186,22 @@ func url2Stat(urlStr string, isFetchMeta bool, encKeyDB map[string][]prefixSSEPa
 	return client, content, nil
 }
 
+// url2StatVersion returns stat info of a version of the object at URL.
+func url2StatVersion(urlStr, versionID string, encKeyDB map[string][]prefixSSEPair) (client Client, content *clientContent, err *probe.Error) {
+	client, err = newClient(urlStr)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	alias, _ := url2Alias(urlStr)
+	sse := getSSE(urlStr, encKeyDB[alias])
+
+	content, err = client.StatVersion(versionID, sse)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr, versionID)
+	}
+	return client, content, nil
+}
+
 // url2Alias separates alias and path from the URL. Aliased URL is of
 // the form alias/path/to/blah.
 func url2Alias(aliasedURL string) (alias, path string) {
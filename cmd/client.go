@@ -49,7 +49,7 @@ type Client interface {
 	List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent
 
 	// Bucket operations
-	MakeBucket(region string, ignoreExisting bool) *probe.Error
+	MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error
 
 	// Access policy operations.
 	GetAccess() (access string, policyJSON string, error *probe.Error)
@@ -76,6 +76,25 @@ type Client interface {
 	// Delete operations
 	Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) (errorCh <-chan *probe.Error)
 
+	// Versioning operations
+	ListVersions(isRecursive bool) <-chan *clientContent
+	StatVersion(versionID string, sse encrypt.ServerSide) (content *clientContent, err *probe.Error)
+	GetVersion(versionID string, sse encrypt.ServerSide) (reader io.ReadCloser, err *probe.Error)
+	RemoveVersion(versionID string) *probe.Error
+	GetVersioning() (status string, err *probe.Error)
+	SetVersioning(status string) *probe.Error
+
+	// Tagging operations, tags of the bucket are used when the url
+	// points to a bucket.
+	GetTags() ([]objectTag, *probe.Error)
+	SetTags(tags []objectTag) *probe.Error
+
+	// Object lock operations
+	GetRetention() (mode string, retainUntil time.Time, err *probe.Error)
+	SetRetention(mode string, retainUntil time.Time, bypassGovernance bool) *probe.Error
+	GetLegalHold() (status string, err *probe.Error)
+	SetLegalHold(status string) *probe.Error
+
 	// GetURL returns back internal url
 	GetURL() clientURL
 }
@@ -89,8 +108,16 @@ type clientContent struct {
 	Metadata          map[string]string
 	UserMetadata      map[string]string
 	ETag              string
+	StorageClass      string
 	Expires           time.Time
 	EncryptionHeaders map[string]string
+	VersionID         string
+	IsLatest          bool
+	IsDeleteMarker    bool
+	Tags              map[string]string
+	RetentionMode     string
+	RetainUntilDate   time.Time
+	LegalHold         string
 	Err               *probe.Error
 }
 
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -141,33 +142,44 @@ func getSourceStreamMetadataFromURL(urlStr string, encKeyDB map[string][]prefixS
 		return nil, nil, err.Trace(urlStr)
 	}
 	sseKey := getSSE(urlStr, encKeyDB[alias])
-	return getSourceStream(alias, urlStrFull, true, sseKey)
+	return getSourceStream(alias, urlStrFull, "", true, sseKey)
 }
 
-// getSourceStreamFromURL gets a reader from URL.
-func getSourceStreamFromURL(urlStr string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser, err *probe.Error) {
+// getSourceStreamFromURL gets a reader from URL, of a specific
+// version of the object if versionID is not empty.
+func getSourceStreamFromURL(urlStr, versionID string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser, err *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return nil, err.Trace(urlStr)
 	}
 	sse := getSSE(urlStr, encKeyDB[alias])
-	reader, _, err = getSourceStream(alias, urlStrFull, false, sse)
+	reader, _, err = getSourceStream(alias, urlStrFull, versionID, false, sse)
 	return reader, err
 }
 
-// getSourceStream gets a reader from URL.
-func getSourceStream(alias string, urlStr string, fetchStat bool, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
+// getSourceStream gets a reader from URL, of a specific version of
+// the object if versionID is not empty.
+func getSourceStream(alias string, urlStr string, versionID string, fetchStat bool, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
 	sourceClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
-	reader, err = sourceClnt.Get(sse)
+	if versionID != "" {
+		reader, err = sourceClnt.GetVersion(versionID, sse)
+	} else {
+		reader, err = sourceClnt.Get(sse)
+	}
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
 	metadata = make(map[string]string)
 	if fetchStat {
-		st, err := sourceClnt.Stat(false, true, sse)
+		var st *clientContent
+		if versionID != "" {
+			st, err = sourceClnt.StatVersion(versionID, sse)
+		} else {
+			st, err = sourceClnt.Stat(false, true, sse)
+		}
 		if err != nil {
 			return nil, nil, err.Trace(alias, urlStr)
 		}
@@ -219,16 +231,16 @@ func putTargetStream(ctx context.Context, alias string, urlStr string, reader io
 	return n, nil
 }
 
-// putTargetStreamWithURL writes to URL from reader. If length=-1, read until EOF.
-func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide) (int64, *probe.Error) {
+// putTargetStreamWithURL writes to URL from reader with metadata. If length=-1, read until EOF.
+func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, metadata map[string]string, sse encrypt.ServerSide) (int64, *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
 	}
-	contentType := guessURLContentType(urlStr)
-	metadata := map[string]string{
-		"Content-Type": contentType,
+	if metadata == nil {
+		metadata = make(map[string]string)
 	}
+	metadata["Content-Type"] = guessURLContentType(urlStr)
 	return putTargetStream(context.Background(), alias, urlStrFull, reader, size, metadata, nil, sse)
 }
 
@@ -270,9 +282,43 @@ func createUserMetadata(sourceAlias, sourceURLStr string, srcSSE encrypt.ServerS
 	return metadata, nil
 }
 
+// endpointKey returns the scheme and host of an endpoint URL, with the
+// default port of the scheme if none is given.
+func endpointKey(urlStr string) string {
+	u, e := url.Parse(urlStr)
+	if e != nil || u.Host == "" {
+		return ""
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if u.Port() == "" {
+		switch scheme {
+		case "http":
+			host += ":80"
+		case "https":
+			host += ":443"
+		}
+	}
+	return scheme + "://" + host + strings.TrimRight(u.Path, "/")
+}
+
+// isSameEndpoint returns true if two aliases point to the same server
+// with the same credentials, objects can then be copied between them
+// by the server.
+func isSameEndpoint(sourceAlias, targetAlias string) bool {
+	_, _, sourceCfg := mustExpandAlias(sourceAlias)
+	_, _, targetCfg := mustExpandAlias(targetAlias)
+	if sourceCfg == nil || targetCfg == nil {
+		return false
+	}
+	sourceKey := endpointKey(sourceCfg.URL)
+	return sourceKey != "" && sourceKey == endpointKey(targetCfg.URL) &&
+		sourceCfg.AccessKey == targetCfg.AccessKey && sourceCfg.SecretKey == targetCfg.SecretKey
+}
+
 // uploadSourceToTargetURL - uploads to targetURL from source.
-// optionally optimizes copy for object sizes <= 5GiB by using
-// server side copy operation.
+// optionally optimizes copy by using server side copy operation,
+// in parts for object sizes > 5GiB.
 func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, encKeyDB map[string][]prefixSSEPair) URLs {
 	sourceAlias := urls.SourceAlias
 	sourceURL := urls.SourceContent.URL
@@ -286,13 +332,19 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	srcSSE := getSSE(sourcePath, encKeyDB[sourceAlias])
 	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Optimize for server side copy if the host is same, also when
+	// the aliases differ but point to the same server with the same
+	// credentials. Versions of objects are always streamed.
+	sameHost := sourceAlias == targetAlias || isSameEndpoint(sourceAlias, targetAlias)
+	if sameHost && urls.SourceContent.VersionID == "" {
 
 		metadata, err := createUserMetadata(sourceAlias, sourceURL.String(), srcSSE, urls)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
+		if tagsStr, ok := urls.TargetContent.Metadata[tagsMetadataKey]; ok {
+			metadata[tagsMetadataKey] = tagsStr
+		}
 
 		sourcePath := filepath.ToSlash(sourceURL.Path)
 		err = copySourceToTargetURL(targetAlias, targetURL.String(), sourcePath, length, progress, srcSSE, tgtSSE, metadata)
@@ -300,9 +352,11 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 	} else {
+		// Throttle the stream if bandwidth limits are set.
+		progress = newLimitedProgress(progress, sourceURL.Type, targetURL.Type)
 
 		// Proceed with regular stream copy.
-		reader, metadata, err := getSourceStream(sourceAlias, sourceURL.String(), true, srcSSE)
+		reader, metadata, err := getSourceStream(sourceAlias, sourceURL.String(), urls.SourceContent.VersionID, true, srcSSE)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
@@ -202,6 +202,26 @@ var completeCmds = map[string]complete.Predictor{
 	"/event/list":   aliasCompleter,
 	"/event/remove": aliasCompleter,
 
+	"/ilm/list":   aliasCompleter,
+	"/ilm/add":    aliasCompleter,
+	"/ilm/remove": aliasCompleter,
+	"/ilm/export": aliasCompleter,
+	"/ilm/import": aliasCompleter,
+
+	"/tag/set":    aliasCompleter,
+	"/tag/list":   aliasCompleter,
+	"/tag/remove": aliasCompleter,
+
+	"/retention/set":   aliasCompleter,
+	"/retention/clear": aliasCompleter,
+	"/retention/info":  aliasCompleter,
+
+	"/legalhold/set":   aliasCompleter,
+	"/legalhold/clear": aliasCompleter,
+	"/legalhold/info":  aliasCompleter,
+
+	"/encrypt/rotate": aliasCompleter,
+
 	"/session/clear":  nil,
 	"/session/list":   nil,
 	"/session/resume": nil,
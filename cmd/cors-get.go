@@ -0,0 +1,67 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var corsGetCmd = cli.Command{
+	Name:   "get",
+	Usage:  "get bucket CORS rules as JSON",
+	Action: mainCORSGet,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the CORS rules of a bucket.
+     $ {{.HelpName}} s3/mybucket
+
+  2. Save the CORS rules of a bucket to a file.
+     $ {{.HelpName}} s3/mybucket > cors.json
+`,
+}
+
+// checkCORSGetSyntax - validate all the passed arguments
+func checkCORSGetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
+	}
+}
+
+func mainCORSGet(ctx *cli.Context) error {
+	console.SetColor("CORS", color.New(color.FgGreen, color.Bold))
+
+	checkCORSGetSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	cors, err := newCORSClient(urlStr).GetCORS()
+	fatalIf(err, "Unable to get the CORS configuration of `"+urlStr+"`.")
+
+	printMsg(corsMessage{op: "get", URL: urlStr, CORS: cors})
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	corsFlags = []cli.Flag{}
+)
+
+var corsCmd = cli.Command{
+	Name:            "cors",
+	Usage:           "manage bucket CORS configuration",
+	HideHelpCommand: true,
+	Action:          mainCORS,
+	Before:          setGlobalsFromContext,
+	Flags:           append(corsFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		corsSetCmd,
+		corsGetCmd,
+		corsRemoveCmd,
+	},
+}
+
+// mainCORS is the handle for "mc cors" command.
+func mainCORS(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "get", "remove" have their own main.
+}
+
+// newCORSClient returns the S3 client of the bucket at urlStr.
+func newCORSClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	return s3Client
+}
+
+// corsMessage container for CORS configuration messages.
+type corsMessage struct {
+	op     string
+	Status string             `json:"status"`
+	URL    string             `json:"url"`
+	CORS   *corsConfiguration `json:"cors,omitempty"`
+}
+
+func (c corsMessage) String() string {
+	switch c.op {
+	case "get":
+		if c.CORS == nil {
+			return console.Colorize("CORS", fmt.Sprintf("No CORS configuration set on `%s`.", c.URL))
+		}
+		corsJSONBytes, e := json.MarshalIndent(c.CORS, "", " ")
+		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+		return string(corsJSONBytes)
+	case "set":
+		return console.Colorize("CORS", fmt.Sprintf("CORS configuration set on `%s`.", c.URL))
+	case "remove":
+		return console.Colorize("CORS", fmt.Sprintf("CORS configuration removed from `%s`.", c.URL))
+	}
+	return ""
+}
+
+func (c corsMessage) JSON() string {
+	c.Status = "success"
+	corsMessageJSONBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(corsMessageJSONBytes)
+}
@@ -0,0 +1,63 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var corsRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove all bucket CORS rules",
+	Action: mainCORSRemove,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the CORS rules of a bucket.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// checkCORSRemoveSyntax - validate all the passed arguments
+func checkCORSRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+func mainCORSRemove(ctx *cli.Context) error {
+	console.SetColor("CORS", color.New(color.FgGreen, color.Bold))
+
+	checkCORSRemoveSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	fatalIf(newCORSClient(urlStr).DeleteCORS(), "Unable to remove the CORS configuration of `"+urlStr+"`.")
+
+	printMsg(corsMessage{op: "remove", URL: urlStr})
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var corsSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set bucket CORS rules from JSON or XML",
+	Action: mainCORSSet,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [FILE]
+
+  The CORS configuration is read from STDIN if FILE is not given. It replaces all
+  existing CORS rules of the bucket. The JSON format is the one of 'mc cors get',
+  the output of 'aws s3api get-bucket-cors' is accepted too.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Set the CORS rules of a bucket from a file.
+     $ {{.HelpName}} s3/mybucket cors.json
+
+  2. Copy the CORS rules of a bucket to another bucket.
+     $ mc cors get s3/mybucket | {{.HelpName}} myminio/mybucket
+`,
+}
+
+// checkCORSSetSyntax - validate all the passed arguments
+func checkCORSSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+func mainCORSSet(ctx *cli.Context) error {
+	console.SetColor("CORS", color.New(color.FgGreen, color.Bold))
+
+	checkCORSSetSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	var data []byte
+	var e error
+	if len(ctx.Args()) == 2 {
+		data, e = ioutil.ReadFile(ctx.Args().Get(1))
+	} else {
+		data, e = ioutil.ReadAll(os.Stdin)
+	}
+	fatalIf(probe.NewError(e).Trace(ctx.Args()...), "Unable to read the CORS configuration.")
+
+	cors, err := parseCORSConfiguration(data)
+	fatalIf(err, "Invalid CORS configuration.")
+
+	fatalIf(newCORSClient(urlStr).SetCORS(cors), "Unable to set the CORS configuration of `"+urlStr+"`.")
+
+	printMsg(corsMessage{op: "set", URL: urlStr})
+	return nil
+}
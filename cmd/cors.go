@@ -0,0 +1,124 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Maximum number of CORS rules of a bucket.
+const corsMaxRules = 100
+
+// corsMethods are the methods CORS rules can allow.
+var corsMethods = []string{"GET", "PUT", "HEAD", "POST", "DELETE"}
+
+// corsConfiguration is the CORS configuration of a bucket, the JSON
+// field names also match the output of 'aws s3api get-bucket-cors'.
+type corsConfiguration struct {
+	XMLName xml.Name   `xml:"CORSConfiguration" json:"-"`
+	Rules   []corsRule `xml:"CORSRule" json:"corsRules"`
+}
+
+// corsRule allows the requests of origins with some methods and
+// headers.
+type corsRule struct {
+	ID             string   `xml:"ID,omitempty" json:"id,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin" json:"allowedOrigins"`
+	AllowedMethods []string `xml:"AllowedMethod" json:"allowedMethods"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty" json:"allowedHeaders,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty" json:"exposeHeaders,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty" json:"maxAgeSeconds,omitempty"`
+}
+
+// validateCORSRule verifies that a rule can be applied.
+func validateCORSRule(rule corsRule) *probe.Error {
+	if len(rule.AllowedOrigins) == 0 {
+		return probe.NewError(errors.New("rule has no allowed origin")).Trace(rule.ID)
+	}
+	for _, origin := range rule.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return probe.NewError(fmt.Errorf("origin `%s` has more than one wildcard", origin)).Trace(rule.ID)
+		}
+	}
+	if len(rule.AllowedMethods) == 0 {
+		return probe.NewError(errors.New("rule has no allowed method")).Trace(rule.ID)
+	}
+	for _, method := range rule.AllowedMethods {
+		valid := false
+		for _, m := range corsMethods {
+			if method == m {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return probe.NewError(fmt.Errorf("method `%s` is not one of %s", method, strings.Join(corsMethods, ", "))).Trace(rule.ID)
+		}
+	}
+	for _, header := range rule.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return probe.NewError(fmt.Errorf("header `%s` has more than one wildcard", header)).Trace(rule.ID)
+		}
+	}
+	if rule.MaxAgeSeconds < 0 {
+		return probe.NewError(errors.New("max age must be positive")).Trace(rule.ID)
+	}
+	return nil
+}
+
+// validate verifies that the configuration has between 1 and 100
+// valid rules.
+func (c *corsConfiguration) validate() *probe.Error {
+	if len(c.Rules) == 0 {
+		return probe.NewError(errors.New("no CORS rule given"))
+	}
+	if len(c.Rules) > corsMaxRules {
+		return probe.NewError(fmt.Errorf("%d CORS rules given, at most %d are allowed", len(c.Rules), corsMaxRules))
+	}
+	for i, rule := range c.Rules {
+		if err := validateCORSRule(rule); err != nil {
+			return err.Trace(fmt.Sprintf("rule %d", i+1))
+		}
+	}
+	return nil
+}
+
+// parseCORSConfiguration parses and validates a CORS configuration in
+// the JSON format of 'mc cors get' or in the XML format of the S3 API.
+func parseCORSConfiguration(data []byte) (*corsConfiguration, *probe.Error) {
+	cors := &corsConfiguration{}
+	var e error
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		e = xml.Unmarshal(data, cors)
+	} else {
+		e = json.Unmarshal(data, cors)
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if err := cors.validate(); err != nil {
+		return nil, err
+	}
+	return cors, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCORSConfiguration(t *testing.T) {
+	testCases := []struct {
+		data    string
+		success bool
+	}{
+		{`{"corsRules": [{"allowedOrigins": ["*"], "allowedMethods": ["GET"]}]}`, true},
+		// Output of 'aws s3api get-bucket-cors'.
+		{`{"CORSRules": [{"AllowedOrigins": ["https://*.example.com"], "AllowedMethods": ["GET", "PUT"], "AllowedHeaders": ["*"], "MaxAgeSeconds": 3000}]}`, true},
+		{`<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>HEAD</AllowedMethod><ExposeHeader>ETag</ExposeHeader></CORSRule></CORSConfiguration>`, true},
+		{`{"corsRules": []}`, false},
+		{`{"corsRules": [{"allowedMethods": ["GET"]}]}`, false},
+		{`{"corsRules": [{"allowedOrigins": ["*"]}]}`, false},
+		{`{"corsRules": [{"allowedOrigins": ["*"], "allowedMethods": ["PATCH"]}]}`, false},
+		{`{"corsRules": [{"allowedOrigins": ["*.*.com"], "allowedMethods": ["GET"]}]}`, false},
+		{`{"corsRules": [{"allowedOrigins": ["*"], "allowedMethods": ["GET"], "maxAgeSeconds": -1}]}`, false},
+		{`<CORSConfiguration><CORSRule>`, false},
+	}
+	for i, testCase := range testCases {
+		_, err := parseCORSConfiguration([]byte(testCase.data))
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	// At most 100 rules are allowed.
+	rules := make([]string, corsMaxRules+1)
+	for i := range rules {
+		rules[i] = `{"allowedOrigins": ["*"], "allowedMethods": ["GET"]}`
+	}
+	if _, err := parseCORSConfiguration([]byte(`{"corsRules": [` + strings.Join(rules, ",") + `]}`)); err == nil {
+		t.Errorf("expected an error with %d rules", len(rules))
+	}
+}
+
+func TestCORSConfigurationRoundTrip(t *testing.T) {
+	cors := &corsConfiguration{Rules: []corsRule{{
+		ID:             "app",
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "HEAD"},
+		AllowedHeaders: []string{"*"},
+		ExposeHeaders:  []string{"ETag"},
+		MaxAgeSeconds:  3000,
+	}}}
+	data, e := json.Marshal(cors)
+	if e != nil {
+		t.Fatal(e)
+	}
+	parsed, err := parseCORSConfiguration(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed.Rules, cors.Rules) {
+		t.Errorf("expected %+v, got %+v", cors.Rules, parsed.Rules)
+	}
+}
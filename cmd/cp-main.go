@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -41,26 +42,26 @@ var (
 			Name:  "recursive, r",
 			Usage: "copy recursively",
 		},
-		cli.StringFlag{
-			Name:  "older-than",
-			Usage: "copy objects older than L days, M hours and N minutes",
-		},
-		cli.StringFlag{
-			Name:  "newer-than",
-			Usage: "copy objects newer than L days, M hours and N minutes",
-		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object(s) on target",
 		},
 		cli.StringFlag{
 			Name:  "encrypt",
-			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
+			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys, 'prefix=kms:key-id' for SSE-KMS)",
 		},
 		cli.StringFlag{
 			Name:  "attr",
 			Usage: "add custom metadata for the object",
 		},
+		cli.StringFlag{
+			Name:  "version-id",
+			Usage: "copy a specific version of the source object",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on the new object(s) on target, e.g. 'key1=value1&key2=value2'",
+		},
 	}
 )
 
@@ -73,7 +74,7 @@ var cpCmd = cli.Command{
 	Usage:  "copy objects",
 	Action: mainCopy,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(append(append(append(append(cpFlags, ioFlags...), filterFlags...), sizeFilterFlags...), ageFilterFlags...), limitFlags...), parallelFlags...), retryFlags...), reportFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -83,8 +84,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+` + filterUnitsHelp + `
 ENVIRONMENT VARIABLES:
-  MC_ENCRYPT:      list of comma delimited prefixes
+  MC_ENCRYPT:      list of comma delimited prefixes or prefix=kms:key-id values
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
 EXAMPLES:
@@ -127,6 +129,31 @@ EXAMPLES:
 
   13. Copy a text file to an object storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
       $ {{.HelpName}} --storage-class REDUCED_REDUNDANCY myobject.txt play/mybucket
+
+  14. Copy a local folder recursively to MinIO cloud storage, limiting the upload bandwidth to 10MiB/s.
+      $ {{.HelpName}} --recursive --limit-upload 10MiB/s backup/2014/ play/archive/
+
+  15. Copy a local folder recursively to MinIO cloud storage using exactly 4 parallel transfers.
+      $ {{.HelpName}} --recursive --parallel 4 backup/2014/ play/archive/
+
+  16. Copy a local folder recursively to MinIO cloud storage, retrying each failed transfer up to 5 times.
+      $ {{.HelpName}} --recursive --retry 5 backup/2014/ play/archive/
+
+  17. Copy a local folder recursively to MinIO cloud storage and write a JSON report of every copied or failed object.
+      $ {{.HelpName}} --recursive --report copy-report.json backup/2014/ play/archive/
+
+  18. Copy all log files larger than 1MiB from a local folder recursively to MinIO cloud storage, except the ones under 'tmp'.
+      $ {{.HelpName}} --recursive --include "*.log" --exclude "tmp/*" --larger 1MiB /var/log/ play/logs/
+
+  19. Copy a specific version of an object from Amazon S3 cloud storage to a local file.
+      $ {{.HelpName}} --version-id "3a6c3e5f-24a1-4ed4-9c0f-a4f5e6f4e2b1" s3/mybucket/myobject.txt myobject.txt
+
+  20. Copy a local folder recursively to Amazon S3 cloud storage and tag the uploaded objects.
+      $ {{.HelpName}} --recursive --tags "project=backup&owner=ops" backup/2014/ s3/archive/
+
+  21. Copy a local folder recursively to MinIO cloud storage, encrypting objects under 'secret/' with the KMS key
+      'my-key-id' and all other objects with server managed keys.
+      $ {{.HelpName}} --recursive --encrypt "myminio/documents/,myminio/documents/secret/=kms:my-key-id" documents/ myminio/documents/
  `,
 }
 
@@ -167,8 +194,18 @@ type ProgressReader interface {
 	Progress
 }
 
-// doCopy - Copy a singe file from source to destination
-func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair) URLs {
+// doCopy - Copy a singe file from source to destination, the source
+// is removed once the copy is verified if isMove is set.
+func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, maxRetries int, encKeyDB map[string][]prefixSSEPair, isMove bool) (status URLs) {
+	start := UTCNow()
+	reportStatus := reportCopied
+	if isMove {
+		reportStatus = reportMoved
+	}
+	defer func() {
+		globalTransferReport.addURLs(reportStatus, status, time.Since(start))
+	}()
+
 	if cpURLs.Error != nil {
 		cpURLs.Error = cpURLs.Error.Trace()
 		return cpURLs
@@ -193,11 +230,15 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 			TotalSize:  cpURLs.TotalSize,
 		})
 	}
-	return uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB)
+	if isMove {
+		return moveSourceToTargetURL(ctx, cpURLs, pg, maxRetries, encKeyDB)
+	}
+	return uploadSourceToTargetURLWithRetry(ctx, cpURLs, pg, maxRetries, encKeyDB)
 }
 
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
 func doCopyFake(cpURLs URLs, pg Progress) URLs {
+	globalTransferReport.addURLs(reportSkipped, cpURLs, 0)
 	if progressReader, ok := pg.(*progressBar); ok {
 		progressReader.ProgressBar.Add64(cpURLs.SourceContent.Size)
 	}
@@ -217,8 +258,9 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	// Access recursive flag inside the session header.
 	isRecursive := session.Header.CommandBoolFlags["recursive"]
 
-	olderThan := session.Header.CommandStringFlags["older-than"]
-	newerThan := session.Header.CommandStringFlags["newer-than"]
+	filter, err := newObjectFilter(session.Header.CommandStringFlags)
+	fatalIf(err, "Unable to parse filters.")
+
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
@@ -231,7 +273,10 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	if !globalQuiet && !globalJSON { // set up progress bar
 		scanBar = scanBarFactory()
 	}
-	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, encKeyDB)
+	// Access version id inside the session header.
+	versionID := session.Header.CommandStringFlags["version-id"]
+
+	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, versionID, filter, encKeyDB)
 	done := false
 	for !done {
 		select {
@@ -259,16 +304,6 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				fatalIf(probe.NewError(e), "Unable to prepare URL for copying. Error in JSON marshaling.")
 			}
 
-			// Skip objects older than --older-than parameter if specified
-			if olderThan != "" && isOlder(cpURLs.SourceContent.Time, olderThan) {
-				continue
-			}
-
-			// Skip objects newer than --newer-than parameter if specified
-			if newerThan != "" && isNewer(cpURLs.SourceContent.Time, newerThan) {
-				continue
-			}
-
 			fmt.Fprintln(dataFP, string(jsonData))
 			if !globalQuiet && !globalJSON {
 				scanBar(cpURLs.SourceContent.URL.String())
@@ -291,6 +326,13 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	session.Save()
 }
 
+// isCopyErrorFatal returns true if err stops a copy session so that it
+// can be resumed. Objects failing for known reasons or still failing
+// after their retries are listed at the end of the session instead.
+func isCopyErrorFatal(err *probe.Error) bool {
+	return !isErrIgnored(err) && !isErrRetryable(err)
+}
+
 func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) error {
 	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 
@@ -300,6 +342,13 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 		doPrepareCopyURLs(session, trapCh, cancelCopy)
 	}
 
+	// Sources of 'mv' sessions are removed once copied.
+	isMove := session.Header.CommandType == "mv"
+	action := "copy"
+	if isMove {
+		action = "move"
+	}
+
 	// Prepare URL scanner from session data file.
 	urlScanner := bufio.NewScanner(session.NewDataReader())
 	// isCopied returns true if an object has been already copied
@@ -319,7 +368,17 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 	var quitCh = make(chan struct{})
 	var statusCh = make(chan URLs)
 
-	parallel, queueCh := newParallelManager(statusCh)
+	minWorkers, maxWorkers, err := parallelLimits(session.Header.CommandIntFlags["parallel"],
+		session.Header.CommandIntFlags["min-parallel"], session.Header.CommandIntFlags["max-parallel"])
+	fatalIf(err, "Unable to parse number of parallel transfers.")
+
+	parallel, queueCh := newParallelManager(statusCh, minWorkers, maxWorkers)
+
+	// Sessions saved by older versions do not record retries.
+	maxRetries, ok := session.Header.CommandIntFlags["retry"]
+	if !ok {
+		maxRetries = defaultMaxRetries
+	}
 
 	go func() {
 		gracefulStop := func() {
@@ -368,6 +427,14 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = session.Header.CommandStringFlags["storage-class"]
 				}
 
+				// Check and handle tags if passed in command line args
+				if tagsStr := session.Header.CommandStringFlags["tags"]; tagsStr != "" {
+					if cpURLs.TargetContent.Metadata == nil {
+						cpURLs.TargetContent.Metadata = make(map[string]string)
+					}
+					cpURLs.TargetContent.Metadata[tagsMetadataKey] = tagsStr
+				}
+
 				//	metaMap, metaSet := session.Header.UserMetaData
 
 				// Check and handle metadata if passed in command line args
@@ -388,7 +455,7 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					}
 				} else {
 					queueCh <- func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB)
+						return doCopy(ctx, cpURLs, pg, maxRetries, encKeyDB, isMove)
 					}
 				}
 			}
@@ -396,6 +463,9 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 	}()
 
 	var retErr error
+	var failed []string
+	// movedFolders are the local folders of the moved files.
+	movedFolders := make(map[string]bool)
 
 loop:
 	for {
@@ -416,6 +486,9 @@ loop:
 			if cpURLs.Error == nil {
 				session.Header.LastCopied = cpURLs.SourceContent.URL.String()
 				session.Save()
+				if isMove && cpURLs.SourceContent.URL.Type == fileSystem {
+					movedFolders[filepath.Dir(cpURLs.SourceContent.URL.Path)] = true
+				}
 			} else {
 
 				// Set exit status for any copy error
@@ -427,8 +500,9 @@ loop:
 					console.Eraseline()
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
-					fmt.Sprintf("Failed to copy `%s`.", cpURLs.SourceContent.URL.String()))
-				if isErrIgnored(cpURLs.Error) {
+					fmt.Sprintf("Failed to %s `%s`.", action, cpURLs.SourceContent.URL.String()))
+				if !isCopyErrorFatal(cpURLs.Error) {
+					failed = append(failed, cpURLs.SourceContent.URL.String())
 					continue loop
 				}
 				// For critical errors we should exit. Session
@@ -449,6 +523,13 @@ loop:
 		}
 	}
 
+	if len(failed) > 0 {
+		printMsg(transferFailuresMessage{Failed: failed})
+	}
+
+	if isMove {
+		removeMovedFolders(session, movedFolders)
+	}
 	return retErr
 }
 
@@ -468,6 +549,11 @@ func getMetaDataEntry(metadataString string) (map[string]string, *probe.Error) {
 
 // mainCopy is the entry point for cp command.
 func mainCopy(ctx *cli.Context) error {
+	return doCopyCommand(ctx, "cp")
+}
+
+// doCopyCommand runs a 'cp' or 'mv' command in a new session.
+func doCopyCommand(ctx *cli.Context, commandType string) error {
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
@@ -481,13 +567,38 @@ func mainCopy(ctx *cli.Context) error {
 
 	// check 'copy' cli arguments.
 	checkCopySyntax(ctx, encKeyDB)
+	if commandType == "mv" {
+		checkMoveSyntax(ctx)
+	}
+
+	// Set bandwidth limits shared by all transfers.
+	err = setBandwidthLimits(ctx.String("limit-upload"), ctx.String("limit-download"))
+	fatalIf(err, "Unable to parse bandwidth limits.")
+
+	// Validate number of parallel transfers.
+	_, _, err = parallelLimits(ctx.Int("parallel"), ctx.Int("min-parallel"), ctx.Int("max-parallel"))
+	fatalIf(err, "Unable to parse number of parallel transfers.")
+
+	if ctx.Int("retry") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("retry")), "Number of retries cannot be negative.")
+	}
+
+	// Absolute path of the report, sessions are resumed from their root path.
+	var reportPath string
+	if ctx.String("report") != "" {
+		var e error
+		reportPath, e = filepath.Abs(ctx.String("report"))
+		fatalIf(probe.NewError(e), "Unable to determine report path.")
+	}
+	err = openTransferReport(reportPath, false)
+	fatalIf(err, "Unable to create report.")
+	defer globalTransferReport.Close()
 
 	// Additional command speific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TransferFailed", color.New(color.FgRed, color.Bold))
 
 	recursive := ctx.Bool("recursive")
-	olderThan := ctx.String("older-than")
-	newerThan := ctx.String("newer-than")
 	storageClass := ctx.String("storage-class")
 	sseKeys := os.Getenv("MC_ENCRYPT_KEY")
 	if key := ctx.String("encrypt-key"); key != "" {
@@ -501,13 +612,21 @@ func mainCopy(ctx *cli.Context) error {
 	sse := ctx.String("encrypt")
 
 	session := newSessionV8()
-	session.Header.CommandType = "cp"
+	session.Header.CommandType = commandType
 	session.Header.CommandBoolFlags["recursive"] = recursive
-	session.Header.CommandStringFlags["older-than"] = olderThan
-	session.Header.CommandStringFlags["newer-than"] = newerThan
+	setFilterFlags(session.Header.CommandStringFlags, getFilterFlags(ctx))
 	session.Header.CommandStringFlags["storage-class"] = storageClass
+	session.Header.CommandStringFlags["version-id"] = ctx.String("version-id")
+	session.Header.CommandStringFlags["tags"] = ctx.String("tags")
 	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 	session.Header.CommandStringFlags["encrypt"] = sse
+	session.Header.CommandStringFlags["limit-upload"] = ctx.String("limit-upload")
+	session.Header.CommandStringFlags["limit-download"] = ctx.String("limit-download")
+	session.Header.CommandIntFlags["parallel"] = ctx.Int("parallel")
+	session.Header.CommandIntFlags["min-parallel"] = ctx.Int("min-parallel")
+	session.Header.CommandIntFlags["max-parallel"] = ctx.Int("max-parallel")
+	session.Header.CommandIntFlags["retry"] = ctx.Int("retry")
+	session.Header.CommandStringFlags["report"] = reportPath
 	session.Header.UserMetaData = userMetaMap
 
 	var e error
@@ -25,7 +25,7 @@ import (
 
 func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	if len(ctx.Args()) < 2 {
-		cli.ShowCommandHelpAndExit(ctx, "cp", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code.
 	}
 
 	// extract URLs.
@@ -37,9 +37,13 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	srcURLs := URLs[:len(URLs)-1]
 	tgtURL := URLs[len(URLs)-1]
 	isRecursive := ctx.Bool("recursive")
+	versionID := ctx.String("version-id")
 
-	// Verify if source(s) exists.
+	// Verify if source(s) exists, versions are verified below.
 	for _, srcURL := range srcURLs {
+		if versionID != "" {
+			break
+		}
 		_, _, err := url2Stat(srcURL, false, encKeyDB)
 		if err != nil {
 			console.Fatalf("Unable to validate source %s\n", srcURL)
@@ -54,6 +58,24 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		}
 	}
 
+	fatalIf(checkTagsFlag(ctx.String("tags")), "Invalid tags.")
+	if _, _, hostCfg := mustExpandAlias(tgtURL); hostCfg == nil && ctx.String("tags") != "" {
+		fatalIf(errInvalidArgument().Trace(tgtURL), "Tags can only be set on objects, target `"+tgtURL+"` is on the local filesystem.")
+	}
+
+	// A version can only be copied from a single object.
+	if versionID != "" {
+		if len(srcURLs) != 1 || isRecursive {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "A version can only be copied from a single source object.")
+		}
+		_, srcContent, err := url2StatVersion(srcURLs[0], versionID, encKeyDB)
+		fatalIf(err.Trace(srcURLs[0], versionID), "Unable to stat version `"+versionID+"` of source `"+srcURLs[0]+"`.")
+		if !srcContent.Type.IsRegular() {
+			fatalIf(errInvalidArgument().Trace(srcURLs[0]), "Source `"+srcURLs[0]+"` is not a file.")
+		}
+		return
+	}
+
 	// Guess CopyURLsType based on source and target URLs.
 	copyURLsType, err := guessCopyURLType(srcURLs, tgtURL, isRecursive, encKeyDB)
 	if err != nil {
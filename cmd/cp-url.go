@@ -149,7 +149,7 @@ func makeCopyContentTypeB(sourceAlias string, sourceContent *clientContent, targ
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -177,6 +177,11 @@ func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyD
 				continue
 			}
 
+			// Skip objects not selected by filters.
+			if !filter.match(filterName(sourceClient.GetURL().Path, sourceContent.URL.Path), sourceContent.Size, sourceContent.Time) {
+				continue
+			}
+
 			// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
 			copyURLsCh <- makeCopyContentTypeC(sourceAlias, sourceClient.GetURL(), sourceContent, targetAlias, targetURL, encKeyDB)
 		}
@@ -199,12 +204,12 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL clientURL, sourceContent
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive bool, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, isRecursive, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, isRecursive, filter, encKeyDB) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -212,25 +217,58 @@ func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive boo
 	return copyURLsCh
 }
 
-// prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+// SINGLE SOURCE VERSION: copy(f@v, f) or copy(f@v, d) -> A
+// prepareCopyURLsVersion - prepares target and source clientURLs for
+// copying a specific version of an object.
+func prepareCopyURLsVersion(sourceURL, targetURL, versionID string, encKeyDB map[string][]prefixSSEPair) URLs {
+	// Extract alias before fiddling with the clientURL.
+	sourceAlias, _, _ := mustExpandAlias(sourceURL)
+
+	_, sourceContent, err := url2StatVersion(sourceURL, versionID, encKeyDB)
+	if err != nil {
+		// Version does not exist or insufficient privileges.
+		return URLs{Error: err.Trace(sourceURL, versionID)}
+	}
+	sourceContent.VersionID = versionID
+
+	if isAliasURLDir(targetURL, encKeyDB) {
+		targetAlias, targetURL, _ := mustExpandAlias(targetURL)
+		return makeCopyContentTypeB(sourceAlias, sourceContent, targetAlias, targetURL, encKeyDB)
+	}
+	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
+	return makeCopyContentTypeA(sourceAlias, sourceContent, targetAlias, targetURL, encKeyDB)
+}
+
+// prepareCopyURLs - prepares target and source clientURLs for copying,
+// of a specific version of a single source if versionID is not empty.
+func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive bool, versionID string, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair) {
 		defer close(copyURLsCh)
+		if versionID != "" {
+			if cURLs := prepareCopyURLsVersion(sourceURLs[0], targetURL, versionID, encKeyDB); matchCopyURLs(cURLs, filter) {
+				copyURLsCh <- cURLs
+			}
+			return
+		}
 		cpType, err := guessCopyURLType(sourceURLs, targetURL, isRecursive, encKeyDB)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
 		switch cpType {
 		case copyURLsTypeA:
-			copyURLsCh <- prepareCopyURLsTypeA(sourceURLs[0], targetURL, encKeyDB)
+			if cURLs := prepareCopyURLsTypeA(sourceURLs[0], targetURL, encKeyDB); matchCopyURLs(cURLs, filter) {
+				copyURLsCh <- cURLs
+			}
 		case copyURLsTypeB:
-			copyURLsCh <- prepareCopyURLsTypeB(sourceURLs[0], targetURL, encKeyDB)
+			if cURLs := prepareCopyURLsTypeB(sourceURLs[0], targetURL, encKeyDB); matchCopyURLs(cURLs, filter) {
+				copyURLsCh <- cURLs
+			}
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, isRecursive, filter, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, isRecursive, filter, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		default:
@@ -240,3 +278,14 @@ func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive bool, en
 
 	return copyURLsCh
 }
+
+// matchCopyURLs - returns true if a single source object is selected by
+// filters, which match its base name. Errors are always returned.
+func matchCopyURLs(cURLs URLs, filter *objectFilter) bool {
+	if cURLs.Error != nil {
+		return true
+	}
+	sourceURL := cURLs.SourceContent.URL
+	name := filepath.Base(filepath.FromSlash(sourceURL.Path))
+	return filter.match(name, cURLs.SourceContent.Size, cURLs.SourceContent.Time)
+}
@@ -29,7 +29,12 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "compare objects of the same size by content (ETag/MD5)",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -49,12 +54,17 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 DESCRIPTION:
-  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents.
+  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents,
+  unless '--checksum' is specified. In that case objects of the same size are compared by their ETags,
+  which are computed from the file contents for local files. This requires reading all local files of
+  the same size in full. ETags of server side encrypted objects are not content checksums, objects
+  listed with encryption headers are compared by time instead.
 
 LEGEND:
   < - object is only in source.
   > - object is only in destination.
   ! - newer object is in source.
+  ! - object content differs between source and destination (with --checksum).
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -62,6 +72,9 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      $ {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare a local folder with a folder on Amazon S3 cloud storage by content.
+     $ {{.HelpName}} --checksum ~/builds s3/mybucket/builds
 `,
 }
 
@@ -90,6 +103,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffSize", "! "+d.SecondURL)
 	case differInTime:
 		msg = console.Colorize("DiffTime", "! "+d.SecondURL)
+	case differInContent:
+		msg = console.Colorize("DiffContent", "! "+d.SecondURL)
 	default:
 		fatalIf(errDummy().Trace(d.FirstURL, d.SecondURL),
 			"Unhandled difference between `"+d.FirstURL+"` and `"+d.SecondURL+"`.")
@@ -103,7 +118,7 @@ func (d diffMessage) JSON() string {
 	d.Status = "success"
 	diffJSONBytes, e := json.MarshalIndent(d, "", " ")
 	fatalIf(probe.NewError(e),
-		"Unable to marshal diff message `"+d.FirstURL+"`, `"+d.SecondURL+"` and `"+string(d.Diff)+"`.")
+		"Unable to marshal diff message `"+d.FirstURL+"`, `"+d.SecondURL+"` and `"+d.Diff.String()+"`.")
 	return string(diffJSONBytes)
 }
 
@@ -146,7 +161,7 @@ func checkDiffSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 }
 
 // doDiffMain runs the diff.
-func doDiffMain(firstURL, secondURL string) error {
+func doDiffMain(firstURL, secondURL string, isChecksum bool) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -174,7 +189,7 @@ func doDiffMain(firstURL, secondURL string) error {
 	}
 
 	// Diff first and second urls.
-	for diffMsg := range objectDifference(firstClient, secondClient, firstURL, secondURL) {
+	for diffMsg := range objectDifference(firstClient, secondClient, firstURL, secondURL, isChecksum) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
@@ -202,10 +217,11 @@ func mainDiff(ctx *cli.Context) error {
 	console.SetColor("DiffType", color.New(color.FgMagenta))
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffContent", color.New(color.FgYellow, color.Bold))
 
 	URLs := ctx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(firstURL, secondURL)
+	return doDiffMain(firstURL, secondURL, ctx.Bool("checksum"))
 }
@@ -17,11 +17,20 @@
 package cmd
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
 	// golang does not support flat keys for path matching, find does
 
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
 	"golang.org/x/text/unicode/norm"
 )
 
@@ -29,12 +38,13 @@ import (
 type differType int
 
 const (
-	differInNone   differType = iota // does not differ
-	differInSize                     // differs in size
-	differInTime                     // differs in time
-	differInType                     // differs in type, exfile/directory
-	differInFirst                    // only in source (FIRST)
-	differInSecond                   // only in target (SECOND)
+	differInNone    differType = iota // does not differ
+	differInSize                      // differs in size
+	differInTime                      // differs in time
+	differInType                      // differs in type, exfile/directory
+	differInFirst                     // only in source (FIRST)
+	differInSecond                    // only in target (SECOND)
+	differInContent                   // differs in content (checksum)
 )
 
 func (d differType) String() string {
@@ -51,21 +61,192 @@ func (d differType) String() string {
 		return "only-in-first"
 	case differInSecond:
 		return "only-in-second"
+	case differInContent:
+		return "content"
 	}
 	return "unknown"
 }
 
-func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, DirNone)
+// etagRegex matches a plain MD5 ETag or a multipart ETag with its parts count.
+var etagRegex = regexp.MustCompile("^[0-9a-f]{32}(-([0-9]+))?$")
+
+// etagPartsCount returns the number of parts of a multipart ETag,
+// zero is returned for a plain MD5 ETag.
+func etagPartsCount(etag string) int {
+	matches := etagRegex.FindStringSubmatch(etag)
+	if matches == nil || matches[2] == "" {
+		return 0
+	}
+	parts, e := strconv.Atoi(matches[2])
+	if e != nil {
+		return 0
+	}
+	return parts
+}
+
+// Part size of uploads made with minio-go, i.e. by mc, it grows by
+// this size for objects which do not fit in maxUploadParts parts.
+const minioPartSize = 128 * humanize.MiByte
+
+// etagPartSizes returns the part sizes which may have been used to
+// upload size bytes in the given number of parts. The part size of
+// minio-go comes first, then the sizes used by other common S3 clients
+// and the smallest multiple of 1MiB giving this number of parts.
+func etagPartSizes(size int64, parts int) []int64 {
+	minioSize := (size/maxUploadParts + minioPartSize - 1) / minioPartSize * minioPartSize
+	if minioSize == 0 {
+		minioSize = minioPartSize
+	}
+	guessedSize := (size + int64(parts) - 1) / int64(parts)
+	guessedSize = (guessedSize + humanize.MiByte - 1) / humanize.MiByte * humanize.MiByte
+
+	var partSizes []int64
+	seen := make(map[int64]bool)
+	for _, partSize := range []int64{minioSize, 8 * humanize.MiByte, 16 * humanize.MiByte, 64 * humanize.MiByte, 128 * humanize.MiByte, guessedSize} {
+		if seen[partSize] || (size+partSize-1)/partSize != int64(parts) {
+			continue
+		}
+		seen[partSize] = true
+		partSizes = append(partSizes, partSize)
+	}
+	return partSizes
+}
+
+// multipartETag computes the ETag S3 gives to the file at fpath when
+// it is uploaded in parts of partSize, i.e. the MD5 of the concatenated
+// MD5 sums of all parts.
+func multipartETag(fpath string, partSize int64) (string, *probe.Error) {
+	f, e := os.Open(fpath)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	defer f.Close()
+
+	var partSums []byte
+	for {
+		hash := md5.New()
+		n, e := io.CopyN(hash, f, partSize)
+		if n > 0 {
+			partSums = append(partSums, hash.Sum(nil)...)
+		}
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return "", probe.NewError(e)
+		}
+	}
+	sum := md5.Sum(partSums)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(partSums)/md5.Size), nil
+}
+
+// fileETag computes the ETag of a local file to compare it with the
+// ETag of an object. The part size of a multipart ETag is not known,
+// the part sizes of common clients are tried until one gives the ETag
+// of the object. isKnown is false if none does, the contents may then
+// be the same.
+func fileETag(fpath string, size int64, objectETag string) (etag string, isKnown bool, err *probe.Error) {
+	parts := etagPartsCount(objectETag)
+	if parts == 0 {
+		f, e := os.Open(fpath)
+		if e != nil {
+			return "", false, probe.NewError(e)
+		}
+		defer f.Close()
+		hash := md5.New()
+		if _, e = io.Copy(hash, f); e != nil {
+			return "", false, probe.NewError(e)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), true, nil
+	}
+
+	for _, partSize := range etagPartSizes(size, parts) {
+		etag, err = multipartETag(fpath, partSize)
+		if err != nil {
+			return "", false, err
+		}
+		if etag == objectETag {
+			return etag, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// isEncryptedObject returns true if content is an object known to be
+// encrypted from the metadata of its listing or stat, its ETag is then
+// not a checksum of its content.
+func isEncryptedObject(content *clientContent) bool {
+	if content.URL.Type != objectStorage {
+		return false
+	}
+	if len(content.EncryptionHeaders) > 0 {
+		return true
+	}
+	for k := range content.Metadata {
+		if strings.HasPrefix(strings.ToLower(k), serverEncryptionKeyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentDiffers compares the content of two regular files of the same size.
+// ETags are used for objects and computed on the fly for local files. isKnown
+// is false when the ETags cannot be compared, e.g. objects uploaded with
+// different multipart layouts, local files matching no known part size of
+// a multipart ETag, encrypted objects or ETags which are not MD5 sums.
+// Encryption is only known for contents listed or fetched with their
+// encryption headers.
+func contentDiffers(srcCtnt, tgtCtnt *clientContent) (differs, isKnown bool, err *probe.Error) {
+	var srcETag, tgtETag string
+	if srcCtnt.URL.Type == objectStorage {
+		srcETag = strings.Trim(srcCtnt.ETag, "\"")
+	}
+	if tgtCtnt.URL.Type == objectStorage {
+		tgtETag = strings.Trim(tgtCtnt.ETag, "\"")
+	}
+	if srcCtnt.URL.Type == fileSystem {
+		srcETag, isKnown, err = fileETag(srcCtnt.URL.Path, srcCtnt.Size, tgtETag)
+		if err != nil {
+			return false, false, err.Trace(srcCtnt.URL.String())
+		}
+		if !isKnown {
+			return false, false, nil
+		}
+	}
+	if tgtCtnt.URL.Type == fileSystem {
+		tgtETag, isKnown, err = fileETag(tgtCtnt.URL.Path, tgtCtnt.Size, srcETag)
+		if err != nil {
+			return false, false, err.Trace(tgtCtnt.URL.String())
+		}
+		if !isKnown {
+			return false, false, nil
+		}
+	}
+	if !etagRegex.MatchString(srcETag) || !etagRegex.MatchString(tgtETag) {
+		return false, false, nil
+	}
+	if etagPartsCount(srcETag) != etagPartsCount(tgtETag) {
+		return false, false, nil
+	}
+	if srcETag != tgtETag && (isEncryptedObject(srcCtnt) || isEncryptedObject(tgtCtnt)) {
+		return false, false, nil
+	}
+	return srcETag != tgtETag, true, nil
+}
+
+func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isChecksum bool) (diffCh chan diffMessage) {
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, isChecksum, DirNone)
 }
 
 func dirDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, DirFirst)
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, false, DirFirst)
 }
 
 // objectDifference function finds the difference between all objects
-// recursively in sorted order from source and target.
-func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
+// recursively in sorted order from source and target. If isChecksum
+// is set, regular files of the same size are compared by content.
+func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar, isChecksum bool, dirOpt DirOpt) (diffCh chan diffMessage) {
 	var (
 		srcEOF, tgtEOF       bool
 		srcOk, tgtOk         bool
@@ -178,6 +359,18 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 					}
 					continue
 				}
+				var differs, isKnown bool
+				if isChecksum && (srcType.IsRegular() && tgtType.IsRegular()) && srcSize == tgtSize {
+					var err *probe.Error
+					differs, isKnown, err = contentDiffers(srcCtnt, tgtCtnt)
+					if err != nil {
+						// Unreadable files are reported and skipped.
+						diffCh <- diffMessage{Error: err.Trace(srcCtnt.URL.String(), tgtCtnt.URL.String())}
+						srcCtnt, srcOk = <-srcCh
+						tgtCtnt, tgtOk = <-tgtCh
+						continue
+					}
+				}
 				if (srcType.IsRegular() && tgtType.IsRegular()) && srcSize != tgtSize {
 					// Regular files differing in size.
 					diffCh <- diffMessage{
@@ -187,6 +380,17 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
+				} else if isKnown {
+					if differs {
+						// Regular files differing in content.
+						diffCh <- diffMessage{
+							FirstURL:      srcCtnt.URL.String(),
+							SecondURL:     tgtCtnt.URL.String(),
+							Diff:          differInContent,
+							firstContent:  srcCtnt,
+							secondContent: tgtCtnt,
+						}
+					}
 				} else if srcTime.After(tgtTime) {
 					// Regular files differing in timestamp.
 					diffCh <- diffMessage{
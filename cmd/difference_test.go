@@ -17,7 +17,15 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/dustin/go-humanize"
 )
 
 var testCases = []struct {
@@ -46,3 +54,109 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestETagPartsCount(t *testing.T) {
+	testCases := []struct {
+		etag  string
+		parts int
+	}{
+		{"", 0},
+		{"d41d8cd98f00b204e9800998ecf8427e", 0},
+		{"d41d8cd98f00b204e9800998ecf8427e-12", 12},
+		{"d41d8cd98f00b204e9800998ecf8427e-", 0},
+		{"not-an-etag-5", 0},
+	}
+	for i, testCase := range testCases {
+		if parts := etagPartsCount(testCase.etag); parts != testCase.parts {
+			t.Errorf("Test %d: expected %d parts, got %d", i+1, testCase.parts, parts)
+		}
+	}
+}
+
+func TestFileETag(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 3*humanize.MiByte)
+	root, e := ioutil.TempDir(os.TempDir(), "diff-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+
+	fpath := filepath.Join(root, "object")
+	if e = ioutil.WriteFile(fpath, data, 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	sum := md5.Sum(data)
+	etag, isKnown, err := fileETag(fpath, int64(len(data)), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := hex.EncodeToString(sum[:]); etag != expected || !isKnown {
+		t.Errorf("Expected ETag %s, got %s", expected, etag)
+	}
+
+	// 3MiB in 2 parts is uploaded as a 2MiB and a 1MiB part.
+	part1 := md5.Sum(data[:2*humanize.MiByte])
+	part2 := md5.Sum(data[2*humanize.MiByte:])
+	sum = md5.Sum(append(part1[:], part2[:]...))
+	expected := hex.EncodeToString(sum[:]) + "-2"
+	etag, isKnown, err = fileETag(fpath, int64(len(data)), expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etag != expected || !isKnown {
+		t.Errorf("Expected ETag %s, got %s", expected, etag)
+	}
+
+	// No part size gives a different multipart ETag.
+	if _, isKnown, err = fileETag(fpath, int64(len(data)), "d41d8cd98f00b204e9800998ecf8427e-2"); err != nil || isKnown {
+		t.Errorf("Expected an unknown ETag, got %t, %v", isKnown, err)
+	}
+
+	// Objects uploaded by mc have 128MiB parts, 128MiB and 1MiB here.
+	size := int64(129 * humanize.MiByte)
+	zeros := make([]byte, 128*humanize.MiByte)
+	part1 = md5.Sum(zeros)
+	part2 = md5.Sum(zeros[:humanize.MiByte])
+	sum = md5.Sum(append(part1[:], part2[:]...))
+	expected = hex.EncodeToString(sum[:]) + "-2"
+	if e = ioutil.WriteFile(fpath, nil, 0644); e != nil {
+		t.Fatal(e)
+	}
+	if e = os.Truncate(fpath, size); e != nil {
+		t.Fatal(e)
+	}
+	etag, isKnown, err = fileETag(fpath, size, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etag != expected || !isKnown {
+		t.Errorf("Expected ETag %s, got %s", expected, etag)
+	}
+}
+
+func TestEncryptedContentDiffers(t *testing.T) {
+	other := &clientContent{URL: *newClientURL("https://s3.amazonaws.com/bucket/other"), ETag: "92eb5ffee6ae2fec3ad71c777531578f", Size: 1}
+	testCases := []struct {
+		content *clientContent
+		isKnown bool
+	}{
+		{&clientContent{}, true},
+		{&clientContent{EncryptionHeaders: map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}}, false},
+		{&clientContent{EncryptionHeaders: map[string]string{"X-Amz-Server-Side-Encryption-Customer-Algorithm": "AES256"}}, false},
+		{&clientContent{Metadata: map[string]string{"X-Amz-Server-Side-Encryption": "AES256"}}, false},
+	}
+	for i, testCase := range testCases {
+		content := testCase.content
+		content.URL = *newClientURL("https://s3.amazonaws.com/bucket/object")
+		content.ETag = "0cc175b9c0f1b6a831c399e269772661"
+		content.Size = 1
+		differs, isKnown, err := contentDiffers(content, other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isKnown != testCase.isKnown || differs != testCase.isKnown {
+			t.Errorf("Test %d: expected known %t, got differs %t, known %t", i+1, testCase.isKnown, differs, isKnown)
+		}
+	}
+}
@@ -19,7 +19,9 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -36,6 +38,14 @@ var (
 			Name:  "depth, d",
 			Usage: "print the total for a folder prefix only if it is N or fewer levels below the command line argument",
 		},
+		cli.StringFlag{
+			Name:  "by",
+			Usage: "break down the usage by 'storage-class' or 'age'",
+		},
+		cli.BoolFlag{
+			Name:  "histogram",
+			Usage: "break down the usage by object size ranges",
+		},
 	}
 )
 
@@ -45,7 +55,7 @@ var duCmd = cli.Command{
 	Usage:  "summarize disk usage folder prefixes recursively",
 	Action: mainDu,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(duFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(duFlags, ioFlags...), filterFlags...), sizeFilterFlags...), ageFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -55,6 +65,7 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+` + filterUnitsHelp + `
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT_KEY: list of comma delimited prefix=secret values
 
@@ -64,20 +75,196 @@ EXAMPLES:
 
    2. Summarize disk usage of 'louis' prefix in 'jazz-songs' bucket upto two levels.
       $ {{.HelpName}} --depth=2 s3/jazz-songs/louis/
+
+   3. Summarize disk usage of all mp3 files larger than 10MiB in 'jazz-songs' bucket.
+      $ {{.HelpName}} --include "*.mp3" --larger 10MiB s3/jazz-songs
+
+   4. Break down disk usage of 'jazz-songs' bucket by storage class.
+      $ {{.HelpName}} --depth=1 --by storage-class s3/jazz-songs
+
+   5. Break down disk usage of 'jazz-songs' bucket by age and object size ranges.
+      $ {{.HelpName}} --depth=1 --by age --histogram s3/jazz-songs
 `,
 }
 
+// Breakdowns supported by --by.
+const (
+	duByStorageClass = "storage-class"
+	duByAge          = "age"
+)
+
+// duAgeBuckets are the upper bounds of the age breakdown, older objects
+// fall in a last bucket.
+var duAgeBuckets = []struct {
+	name string
+	age  time.Duration
+}{
+	{"<30d", 30 * 24 * time.Hour},
+	{"<90d", 90 * 24 * time.Hour},
+	{"<1y", 365 * 24 * time.Hour},
+}
+
+// duSizeBuckets are the upper bounds of the size histogram, larger
+// objects fall in a last bucket.
+var duSizeBuckets = []struct {
+	name string
+	size int64
+}{
+	{"<1KiB", humanize.KiByte},
+	{"<1MiB", humanize.MiByte},
+	{"<16MiB", 16 * humanize.MiByte},
+	{"<128MiB", 128 * humanize.MiByte},
+	{"<1GiB", humanize.GiByte},
+}
+
+// duOptions select the breakdowns computed by du.
+type duOptions struct {
+	by        string
+	histogram bool
+}
+
+// duBucket is the usage of the objects of a breakdown bucket.
+type duBucket struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	Objects int64  `json:"objects"`
+}
+
+// duUsage is the usage of a folder prefix with its breakdowns.
+type duUsage struct {
+	size      int64
+	objects   int64
+	by        []duBucket
+	histogram []duBucket
+}
+
+// addBucket accounts an object of the given size in the named bucket,
+// buckets keep the order in which they are first added.
+func addBucket(buckets []duBucket, name string, size, objects int64) []duBucket {
+	for i := range buckets {
+		if buckets[i].Name == name {
+			buckets[i].Size += size
+			buckets[i].Objects += objects
+			return buckets
+		}
+	}
+	return append(buckets, duBucket{Name: name, Size: size, Objects: objects})
+}
+
+// add accounts an object.
+func (u *duUsage) add(content *clientContent, opts duOptions, now time.Time) {
+	u.size += content.Size
+	u.objects++
+	switch opts.by {
+	case duByStorageClass:
+		class := content.StorageClass
+		if class == "" {
+			// Objects without a storage class are stored as standard.
+			class = "STANDARD"
+		}
+		u.by = addBucket(u.by, class, content.Size, 1)
+	case duByAge:
+		name := ">=1y"
+		for _, bucket := range duAgeBuckets {
+			if now.Sub(content.Time) < bucket.age {
+				name = bucket.name
+				break
+			}
+		}
+		u.by = addBucket(u.by, name, content.Size, 1)
+	}
+	if opts.histogram {
+		name := ">=1GiB"
+		for _, bucket := range duSizeBuckets {
+			if content.Size < bucket.size {
+				name = bucket.name
+				break
+			}
+		}
+		u.histogram = addBucket(u.histogram, name, content.Size, 1)
+	}
+}
+
+// merge accounts the usage of a sub folder prefix.
+func (u *duUsage) merge(sub duUsage) {
+	u.size += sub.size
+	u.objects += sub.objects
+	for _, b := range sub.by {
+		u.by = addBucket(u.by, b.Name, b.Size, b.Objects)
+	}
+	for _, b := range sub.histogram {
+		u.histogram = addBucket(u.histogram, b.Name, b.Size, b.Objects)
+	}
+}
+
+// sortBuckets orders storage classes by name and age or size buckets
+// from the smallest to the largest.
+func (u *duUsage) sortBuckets(opts duOptions) {
+	order := func(buckets []duBucket, names []string) {
+		rank := func(name string) int {
+			for i, n := range names {
+				if n == name {
+					return i
+				}
+			}
+			return len(names)
+		}
+		sort.SliceStable(buckets, func(i, j int) bool {
+			if ri, rj := rank(buckets[i].Name), rank(buckets[j].Name); ri != rj {
+				return ri < rj
+			}
+			return buckets[i].Name < buckets[j].Name
+		})
+	}
+	switch opts.by {
+	case duByStorageClass:
+		order(u.by, nil)
+	case duByAge:
+		var names []string
+		for _, bucket := range duAgeBuckets {
+			names = append(names, bucket.name)
+		}
+		order(u.by, names)
+	}
+	var names []string
+	for _, bucket := range duSizeBuckets {
+		names = append(names, bucket.name)
+	}
+	order(u.histogram, names)
+}
+
 // Structured message depending on the type of console.
 type duMessage struct {
-	Prefix string `json:"prefix"`
-	Size   string `json:"size"`
-	Status string `json:"status"`
+	Prefix       string     `json:"prefix"`
+	Size         string     `json:"size"`
+	Objects      int64      `json:"objects"`
+	StorageClass []duBucket `json:"storageClass,omitempty"`
+	Age          []duBucket `json:"age,omitempty"`
+	Histogram    []duBucket `json:"histogram,omitempty"`
+	Status       string     `json:"status"`
+}
+
+// humanizeSize formats a size the way du prints it.
+func humanizeSize(size int64) string {
+	return strings.Join(strings.Fields(humanize.IBytes(uint64(size))), "")
 }
 
 // Colorized message for console printing.
 func (r duMessage) String() string {
-	return fmt.Sprintf("%s\t%s", console.Colorize("Size", r.Size),
+	msg := fmt.Sprintf("%s\t%s\t%s", console.Colorize("Size", r.Size),
+		console.Colorize("Objects", fmt.Sprintf("%d objects", r.Objects)),
 		console.Colorize("Prefix", r.Prefix))
+	for _, breakdown := range []struct {
+		name    string
+		buckets []duBucket
+	}{{"storage-class", r.StorageClass}, {"age", r.Age}, {"size", r.Histogram}} {
+		for _, b := range breakdown.buckets {
+			msg += fmt.Sprintf("\n\t%s\t%s\t%s", console.Colorize("Size", humanizeSize(b.Size)),
+				console.Colorize("Objects", fmt.Sprintf("%d objects", b.Objects)),
+				console.Colorize("Bucket", breakdown.name+" "+b.Name))
+		}
+	}
+	return msg
 }
 
 // JSON'ified message for scripting.
@@ -87,7 +274,11 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, error) {
+// du - returns the disk usage of urlStr, counting only objects selected
+// by filter. Object names are matched relative to rootPath, the path of
+// the command line argument, which is empty for the first call.
+func du(urlStr string, rootPath string, depth int, filter *objectFilter, encKeyDB map[string][]prefixSSEPair, opts duOptions) (duUsage, error) {
+	var usage duUsage
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 	if !strings.HasSuffix(targetURL, "/") {
 		targetURL += "/"
@@ -96,17 +287,20 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
 		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `"+urlStr+"`.")
-		return 0, exitStatus(globalErrorExitStatus) // End of journey.
+		return usage, exitStatus(globalErrorExitStatus) // End of journey.
+	}
+	if rootPath == "" {
+		rootPath = clnt.GetURL().Path
 	}
 
 	isRecursive := false
 	isIncomplete := false
 	contentCh := clnt.List(isRecursive, isIncomplete, DirFirst)
-	size := int64(0)
+	now := UTCNow()
 	for content := range contentCh {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `"+urlStr+"` recursively.")
-			return 0, exitStatus(globalErrorExitStatus)
+			return usage, exitStatus(globalErrorExitStatus)
 		}
 
 		if content.URL.String() == targetURL {
@@ -123,13 +317,13 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, err := du(subDirAlias, depth, encKeyDB)
+			used, err := du(subDirAlias, rootPath, depth, filter, encKeyDB, opts)
 			if err != nil {
-				return 0, err
+				return usage, err
 			}
-			size += used
-		} else {
-			size += content.Size
+			usage.merge(used)
+		} else if filter.match(filterName(rootPath, content.URL.Path), content.Size, content.Time) {
+			usage.add(content, opts, now)
 		}
 	}
 
@@ -139,20 +333,31 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 			panic(err)
 		}
 
-		printMsg(duMessage{
-			Prefix: strings.Trim(u.Path, "/"),
-			Size:   strings.Join(strings.Fields(humanize.IBytes(uint64(size))), ""),
-			Status: "success",
-		})
+		usage.sortBuckets(opts)
+		msg := duMessage{
+			Prefix:    strings.Trim(u.Path, "/"),
+			Size:      humanizeSize(usage.size),
+			Objects:   usage.objects,
+			Histogram: usage.histogram,
+			Status:    "success",
+		}
+		if opts.by == duByStorageClass {
+			msg.StorageClass = usage.by
+		} else {
+			msg.Age = usage.by
+		}
+		printMsg(msg)
 	}
 
-	return size, nil
+	return usage, nil
 }
 
 // main for du command.
 func mainDu(ctx *cli.Context) error {
 	console.SetColor("Prefix", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
+	console.SetColor("Objects", color.New(color.FgWhite))
+	console.SetColor("Bucket", color.New(color.FgGreen))
 
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(ctx)
@@ -164,12 +369,20 @@ func mainDu(ctx *cli.Context) error {
 		depth = -1
 	}
 
+	filter, err := newObjectFilter(getFilterFlags(ctx))
+	fatalIf(err, "Unable to parse filters.")
+
+	opts := duOptions{by: ctx.String("by"), histogram: ctx.Bool("histogram")}
+	if opts.by != "" && opts.by != duByStorageClass && opts.by != duByAge {
+		fatalIf(errInvalidArgument().Trace(opts.by), "--by must be one of '"+duByStorageClass+"' or '"+duByAge+"'.")
+	}
+
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
 	var duErr error
 	for _, urlStr := range ctx.Args() {
-		if _, err := du(urlStr, depth, encKeyDB); duErr == nil {
+		if _, err := du(urlStr, "", depth, filter, encKeyDB, opts); duErr == nil {
 			duErr = err
 		}
 	}
@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestDuUsage(c *C) {
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	contents := []*clientContent{
+		{Size: 100, Time: now.Add(-day)},
+		{Size: 2 * humanize.MiByte, Time: now.Add(-60 * day), StorageClass: "REDUCED_REDUNDANCY"},
+		{Size: 2 * humanize.GiByte, Time: now.Add(-400 * day), StorageClass: "GLACIER"},
+	}
+
+	opts := duOptions{by: duByAge, histogram: true}
+	var usage, sub duUsage
+	usage.add(contents[2], opts, now)
+	sub.add(contents[0], opts, now)
+	sub.add(contents[1], opts, now)
+	usage.merge(sub)
+	usage.sortBuckets(opts)
+
+	c.Assert(usage.size, Equals, int64(100+2*humanize.MiByte+2*humanize.GiByte))
+	c.Assert(usage.objects, Equals, int64(3))
+	c.Assert(usage.by, DeepEquals, []duBucket{
+		{Name: "<30d", Size: 100, Objects: 1},
+		{Name: "<90d", Size: 2 * humanize.MiByte, Objects: 1},
+		{Name: ">=1y", Size: 2 * humanize.GiByte, Objects: 1},
+	})
+	c.Assert(usage.histogram, DeepEquals, []duBucket{
+		{Name: "<1KiB", Size: 100, Objects: 1},
+		{Name: "<16MiB", Size: 2 * humanize.MiByte, Objects: 1},
+		{Name: ">=1GiB", Size: 2 * humanize.GiByte, Objects: 1},
+	})
+
+	opts = duOptions{by: duByStorageClass}
+	usage = duUsage{}
+	for _, content := range contents {
+		usage.add(content, opts, now)
+	}
+	usage.add(contents[0], opts, now)
+	usage.sortBuckets(opts)
+	c.Assert(usage.by, DeepEquals, []duBucket{
+		{Name: "GLACIER", Size: 2 * humanize.GiByte, Objects: 1},
+		{Name: "REDUCED_REDUNDANCY", Size: 2 * humanize.MiByte, Objects: 1},
+		{Name: "STANDARD", Size: 200, Objects: 2},
+	})
+	c.Assert(usage.histogram, IsNil)
+}
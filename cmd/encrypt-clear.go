@@ -0,0 +1,65 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "remove the default encryption of a bucket",
+	Action: mainEncryptClear,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+  Existing objects stay encrypted, only new objects are no longer encrypted by default.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the default encryption of a bucket.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// checkEncryptClearSyntax - validate all the passed arguments
+func checkEncryptClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+func mainEncryptClear(ctx *cli.Context) error {
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptClearSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	fatalIf(newEncryptClient(urlStr).DeleteEncryption(), "Unable to remove the default encryption of `"+urlStr+"`.")
+
+	printMsg(encryptMessage{op: "clear", URL: urlStr})
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show the default encryption of a bucket",
+	Action: mainEncryptInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the default encryption of a bucket.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// checkEncryptInfoSyntax - validate all the passed arguments
+func checkEncryptInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+func mainEncryptInfo(ctx *cli.Context) error {
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptInfoSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	config, err := newEncryptClient(urlStr).GetEncryption()
+	fatalIf(err, "Unable to get the default encryption of `"+urlStr+"`.")
+
+	msg := encryptMessage{op: "info", URL: urlStr}
+	if config != nil && len(config.Rules) > 0 {
+		msg.Encryption = &bucketEncryption{
+			Algorithm: config.Rules[0].Apply.SSEAlgorithm,
+			KMSKeyID:  config.Rules[0].Apply.KMSMasterKeyID,
+		}
+	}
+	printMsg(msg)
+	return nil
+}
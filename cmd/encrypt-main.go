@@ -0,0 +1,118 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptFlags = []cli.Flag{}
+)
+
+var encryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "manage server-side encryption of buckets and objects",
+	HideHelpCommand: true,
+	Action:          mainEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           append(encryptFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		encryptSetCmd,
+		encryptInfoCmd,
+		encryptClearCmd,
+		encryptRotateCmd,
+	},
+}
+
+// mainEncrypt is the handle for "mc encrypt" command.
+func mainEncrypt(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "rotate" have their own main.
+}
+
+// Default encryption types of a bucket, as given on the command line.
+const (
+	encryptTypeSSES3  = "sse-s3"
+	encryptTypeSSEKMS = "sse-kms"
+)
+
+// newEncryptClient returns the S3 client of the bucket at urlStr.
+func newEncryptClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	return s3Client
+}
+
+// encryptionType returns the type of a default encryption algorithm.
+func encryptionType(algorithm string) string {
+	switch algorithm {
+	case encryptionSSES3:
+		return encryptTypeSSES3
+	case encryptionSSEKMS:
+		return encryptTypeSSEKMS
+	}
+	return algorithm
+}
+
+// encryptMessage container for bucket default encryption messages.
+type encryptMessage struct {
+	op         string
+	Status     string            `json:"status"`
+	URL        string            `json:"url"`
+	Encryption *bucketEncryption `json:"encryption,omitempty"`
+}
+
+func (m encryptMessage) String() string {
+	var encryption string
+	if m.Encryption != nil {
+		encryption = "`" + encryptionType(m.Encryption.Algorithm) + "`"
+		if m.Encryption.KMSKeyID != "" {
+			encryption += " with key `" + m.Encryption.KMSKeyID + "`"
+		}
+	}
+	switch m.op {
+	case "set":
+		return console.Colorize("Encrypt", fmt.Sprintf("Default encryption %s set on `%s`.", encryption, m.URL))
+	case "info":
+		if m.Encryption == nil {
+			return console.Colorize("Encrypt", fmt.Sprintf("No default encryption set on `%s`.", m.URL))
+		}
+		return console.Colorize("Encrypt", fmt.Sprintf("Default encryption of `%s` is %s.", m.URL, encryption))
+	case "clear":
+		return console.Colorize("Encrypt", fmt.Sprintf("Default encryption removed from `%s`.", m.URL))
+	}
+	return ""
+}
+
+func (m encryptMessage) JSON() string {
+	m.Status = "success"
+	encryptMessageJSONBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(encryptMessageJSONBytes)
+}
@@ -0,0 +1,326 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptRotateFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "rotate keys of all objects under the prefix",
+		},
+		cli.StringFlag{
+			Name:  "new-encrypt-key",
+			Usage: "new customer provided keys to encrypt objects with, given as prefix=secret values",
+		},
+	}
+)
+
+var encryptRotateCmd = cli.Command{
+	Name:   "rotate",
+	Usage:  "rotate customer provided encryption keys (SSE-C) of objects",
+	Action: mainEncryptRotate,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(append(encryptRotateFlags, reportFlags...), ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values of the current keys
+
+DESCRIPTION:
+  Objects are encrypted with the new keys by a server-side copy onto themselves, their
+  content is never downloaded. Keys are 32 bytes long, or 44 bytes long when base64
+  encoded. An interrupted rotation can be resumed with 'mc session resume'.
+
+EXAMPLES:
+  1. Rotate the encryption key of an object.
+     $ {{.HelpName}} --encrypt-key "myminio/mybucket=32byteslongsecretkeymustbegiven1" \
+         --new-encrypt-key "myminio/mybucket=32byteslongsecretkeymustbegiven2" myminio/mybucket/myobject.txt
+
+  2. Rotate the encryption keys of all objects under a prefix and write a CSV report.
+     $ {{.HelpName}} --recursive --report rotate.csv --encrypt-key "myminio/mybucket/docs/=32byteslongsecretkeymustbegiven1" \
+         --new-encrypt-key "myminio/mybucket/docs/=32byteslongsecretkeymustbegiven2" myminio/mybucket/docs/
+`,
+}
+
+// rotateMessage container for key rotation messages.
+type rotateMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size"`
+}
+
+func (r rotateMessage) String() string {
+	return console.Colorize("Rotate", fmt.Sprintf("Rotated encryption key of `%s`.", r.URL))
+}
+
+func (r rotateMessage) JSON() string {
+	r.Status = "success"
+	rotateMessageJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(rotateMessageJSONBytes)
+}
+
+// checkEncryptRotateSyntax - validate all the passed arguments
+func checkEncryptRotateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "rotate", 1) // last argument is exit code
+	}
+	if ctx.String("new-encrypt-key") == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "New encryption keys must be given with --new-encrypt-key.")
+	}
+	targetURL := ctx.Args().Get(0)
+	if _, _, hostCfg := mustExpandAlias(targetURL); hostCfg == nil {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Rotating encryption keys is not supported on filesystem.")
+	}
+}
+
+// rotateObjectKey encrypts the object of urls with its new key by a
+// server-side copy onto itself, decrypting it with its current key.
+// Objects which can already be read with their new key are rotated.
+func rotateObjectKey(urls URLs, encKeyDB, newEncKeyDB map[string][]prefixSSEPair) URLs {
+	alias := urls.SourceAlias
+	objectURL := urls.SourceContent.URL.String()
+	objectPath := filepath.ToSlash(filepath.Join(alias, urls.SourceContent.URL.Path))
+
+	newSSE := getSSE(objectPath, newEncKeyDB[alias])
+	if newSSE == nil {
+		return urls.WithError(probe.NewError(errors.New("no new encryption key given for `" + objectPath + "`")))
+	}
+	clnt, err := newClientFromAlias(alias, objectURL)
+	if err != nil {
+		return urls.WithError(err.Trace(objectURL))
+	}
+	sourcePath := filepath.ToSlash(urls.SourceContent.URL.Path)
+	err = clnt.Copy(sourcePath, urls.SourceContent.Size, nil, getSSE(objectPath, encKeyDB[alias]), newSSE, nil)
+	if err != nil {
+		// The key may have been rotated by an interrupted run which
+		// did not save it in the session.
+		if _, statErr := clnt.Stat(false, true, newSSE); statErr == nil {
+			return urls.WithError(nil)
+		}
+		return urls.WithError(err.Trace(objectURL))
+	}
+	return urls.WithError(nil)
+}
+
+// doPrepareRotateURLs scans the target and stores the objects whose
+// keys are rotated in the session.
+func doPrepareRotateURLs(session *sessionV8, trapCh <-chan bool) {
+	targetURL := session.Header.CommandArgs[0]
+	isRecursive := session.Header.CommandBoolFlags["recursive"]
+
+	alias, _, _ := mustExpandAlias(targetURL)
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Cannot parse the provided url.")
+
+	// Create a session data file to store the objects.
+	dataFP := session.NewDataWriter()
+
+	var totalBytes int64
+	var totalObjects int64
+	addObject := func(content *clientContent) {
+		jsonData, e := json.Marshal(URLs{
+			SourceAlias:   alias,
+			SourceContent: content,
+			TargetAlias:   alias,
+			TargetContent: content,
+		})
+		if e != nil {
+			session.Delete()
+			fatalIf(probe.NewError(e), "Unable to prepare URL for rotating keys. Error in JSON marshaling.")
+		}
+		fmt.Fprintln(dataFP, string(jsonData))
+		totalBytes += content.Size
+		totalObjects++
+	}
+
+	if !isRecursive {
+		content, err := clnt.Stat(false, false, nil)
+		if err != nil {
+			session.Delete()
+			fatalIf(err.Trace(targetURL), "Unable to stat `"+targetURL+"`.")
+		}
+		if content.Type.IsDir() {
+			session.Delete()
+			fatalIf(errInvalidArgument().Trace(targetURL), "`"+targetURL+"` is a folder, use --recursive to rotate keys of all objects under it.")
+		}
+		addObject(content)
+	} else {
+		for content := range clnt.List(true, false, DirNone) {
+			select {
+			case <-trapCh:
+				// If we are interrupted during the scanning, we drop the session.
+				session.Delete()
+				os.Exit(0)
+			default:
+			}
+			if content.Err != nil {
+				errorIf(content.Err.Trace(targetURL), "Unable to list `"+targetURL+"`.")
+				continue
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			addObject(content)
+		}
+	}
+	session.Header.TotalBytes = totalBytes
+	session.Header.TotalObjects = totalObjects
+	session.Save()
+}
+
+// doRotateSession rotates the keys of the objects of the session, one
+// object at a time so that the session can be resumed from the last
+// rotated object. The session is not advanced past an object which
+// failed, so that it is rotated again when the session is resumed.
+func doRotateSession(session *sessionV8) error {
+	encKeyDB, err := parseAndValidateEncryptionKeys(session.Header.CommandStringFlags["encrypt-key"], "")
+	fatalIf(err, "Unable to parse encryption keys.")
+	newEncKeyDB, err := parseAndValidateEncryptionKeys(session.Header.CommandStringFlags["new-encrypt-key"], "")
+	fatalIf(err, "Unable to parse new encryption keys.")
+
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	if !session.HasData() {
+		doPrepareRotateURLs(session, trapCh)
+	}
+
+	urlScanner := bufio.NewScanner(session.NewDataReader())
+	// isRotated returns true if the key of an object has been
+	// already rotated. This is useful when we resume from a session.
+	isRotated := isLastFactory(session.Header.LastCopied)
+
+	var retErr error
+	failed := false
+	for urlScanner.Scan() {
+		select {
+		case <-trapCh:
+			session.CloseAndDie()
+		default:
+		}
+
+		var rotateURLs URLs
+		if e := json.Unmarshal([]byte(urlScanner.Text()), &rotateURLs); e != nil {
+			errorIf(probe.NewError(e), "Unable to unmarshal %s", urlScanner.Text())
+			continue
+		}
+		objectURL := rotateURLs.SourceContent.URL.String()
+		if isRotated(objectURL) {
+			globalTransferReport.addURLs(reportSkipped, rotateURLs, 0)
+			continue
+		}
+
+		objectPath := filepath.ToSlash(filepath.Join(rotateURLs.SourceAlias, rotateURLs.SourceContent.URL.Path))
+		start := UTCNow()
+		rotateURLs = rotateObjectKey(rotateURLs, encKeyDB, newEncKeyDB)
+		globalTransferReport.addURLs(reportRotated, rotateURLs, time.Since(start))
+		if rotateURLs.Error != nil {
+			errorIf(rotateURLs.Error.Trace(objectURL), "Failed to rotate encryption key of `"+objectPath+"`.")
+			retErr = exitStatus(globalErrorExitStatus)
+			failed = true
+			continue
+		}
+		printMsg(rotateMessage{
+			URL:  objectPath,
+			Size: rotateURLs.SourceContent.Size,
+		})
+		if !failed {
+			session.Header.LastCopied = objectURL
+			session.Save()
+		}
+	}
+	if e := urlScanner.Err(); e != nil {
+		errorIf(probe.NewError(e), "Unable to read session data.")
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+	return retErr
+}
+
+func mainEncryptRotate(ctx *cli.Context) error {
+	checkEncryptRotateSyntax(ctx)
+
+	console.SetColor("Rotate", color.New(color.FgGreen, color.Bold))
+
+	sseKeys := os.Getenv("MC_ENCRYPT_KEY")
+	if key := ctx.String("encrypt-key"); key != "" {
+		sseKeys = key
+	}
+	var err *probe.Error
+	if sseKeys != "" {
+		sseKeys, err = getDecodedKey(sseKeys)
+		fatalIf(err, "Unable to parse encryption keys.")
+	}
+	_, err = parseAndValidateEncryptionKeys(sseKeys, "")
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	newSSEKeys, err := getDecodedKey(ctx.String("new-encrypt-key"))
+	fatalIf(err, "Unable to parse new encryption keys.")
+	_, err = parseAndValidateEncryptionKeys(newSSEKeys, "")
+	fatalIf(err, "Unable to parse new encryption keys.")
+
+	// Absolute path of the report, sessions are resumed from their root path.
+	var reportPath string
+	if ctx.String("report") != "" {
+		var e error
+		reportPath, e = filepath.Abs(ctx.String("report"))
+		fatalIf(probe.NewError(e), "Unable to determine report path.")
+	}
+	err = openTransferReport(reportPath, false)
+	fatalIf(err, "Unable to create report.")
+	defer globalTransferReport.Close()
+
+	session := newSessionV8()
+	session.Header.CommandType = "encrypt rotate"
+	session.Header.CommandBoolFlags["recursive"] = ctx.Bool("recursive")
+	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
+	session.Header.CommandStringFlags["new-encrypt-key"] = newSSEKeys
+	session.Header.CommandStringFlags["report"] = reportPath
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+
+	session.Header.CommandArgs = ctx.Args()
+	e = doRotateSession(session)
+	session.Delete()
+
+	return e
+}
@@ -0,0 +1,144 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	. "gopkg.in/check.v1"
+)
+
+func TestRotateObjectKeyWithoutNewKey(t *testing.T) {
+	oldKey, err := encrypt.NewSSEC([]byte("32byteslongsecretkeymustbegiven1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := encrypt.NewSSEC([]byte("32byteslongsecretkeymustbegiven2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encKeyDB := map[string][]prefixSSEPair{"myminio": {{Prefix: "myminio/bucket", SSE: oldKey}}}
+	newEncKeyDB := map[string][]prefixSSEPair{"myminio": {{Prefix: "myminio/bucket/docs", SSE: newKey}}}
+
+	urls := URLs{
+		SourceAlias:   "myminio",
+		SourceContent: &clientContent{URL: *newClientURL("https://play.min.io/bucket/photos/1.jpg")},
+		TargetAlias:   "myminio",
+		TargetContent: &clientContent{URL: *newClientURL("https://play.min.io/bucket/photos/1.jpg")},
+	}
+	// Objects without a new key are never rewritten.
+	if urls = rotateObjectKey(urls, encKeyDB, newEncKeyDB); urls.Error == nil {
+		t.Fatal("Expected an error for an object without a new key, got success")
+	}
+}
+
+// rotateHandler is an http.Handler that stores the MD5 sums of the keys
+// of objects encrypted with customer keys, copies of denied objects fail.
+type rotateHandler struct {
+	sync.Mutex
+	objects []string
+	keys    map[string]string
+	denied  map[string]bool
+}
+
+func (h *rotateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	object := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch {
+	case len(r.URL.Query()["location"]) > 0:
+		w.Write([]byte(`<LocationConstraint xmlns="http://doc.s3.amazonaws.com/2006-03-01"></LocationConstraint>`))
+	case r.Method == http.MethodGet:
+		response := "<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated>"
+		for _, object := range h.objects {
+			response += "<Contents><Key>" + object + "</Key><Size>1</Size><LastModified>2019-01-01T00:00:00.000Z</LastModified></Contents>"
+		}
+		w.Write([]byte(response + "</ListBucketResult>"))
+	case r.Method == http.MethodHead:
+		if h.keys[object] != r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Length", "1")
+		w.Header().Set("Last-Modified", "Tue, 01 Jan 2019 00:00:00 GMT")
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		if h.denied[object] {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>"))
+			return
+		}
+		if h.keys[object] != r.Header.Get("X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("<Error><Code>InvalidArgument</Code><Message>Invalid key.</Message></Error>"))
+			return
+		}
+		h.keys[object] = r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5")
+		w.Write([]byte("<CopyObjectResult><ETag>\"etag\"</ETag><LastModified>2019-01-01T00:00:00.000Z</LastModified></CopyObjectResult>"))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (s *TestSuite) TestRotateSessionResume(c *C) {
+	keyMD5 := func(key string) string {
+		sum := md5.Sum([]byte(key))
+		return base64.StdEncoding.EncodeToString(sum[:])
+	}
+	const oldKey, newKey = "32byteslongsecretkeymustbegiven1", "32byteslongsecretkeymustbegiven2"
+	// The key of c was rotated by an interrupted run which did not
+	// save it in the session.
+	handler := &rotateHandler{
+		objects: []string{"a", "b", "c"},
+		keys:    map[string]string{"a": keyMD5(oldKey), "b": keyMD5(oldKey), "c": keyMD5(newKey)},
+		denied:  map[string]bool{"b": true},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV9, *probe.Error) { return newConfigV9(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+	os.Setenv("MC_HOST_rottest", strings.Replace(server.URL, "http://", "http://WLGDGYAQYIGI833EV05A:BYvgJM101sHngl2uzjXS@", 1))
+	defer os.Unsetenv("MC_HOST_rottest")
+
+	session := newSessionV8()
+	defer session.Delete()
+	session.Header.CommandType = "encrypt rotate"
+	session.Header.CommandArgs = []string{"rottest/bucket/"}
+	session.Header.CommandBoolFlags["recursive"] = true
+	session.Header.CommandStringFlags["encrypt-key"] = "rottest/bucket=" + oldKey
+	session.Header.CommandStringFlags["new-encrypt-key"] = "rottest/bucket=" + newKey
+
+	c.Assert(doRotateSession(session), NotNil)
+	c.Assert(handler.keys, DeepEquals, map[string]string{"a": keyMD5(newKey), "b": keyMD5(oldKey), "c": keyMD5(newKey)})
+	// The session is not advanced past the failed object.
+	c.Assert(strings.HasSuffix(session.Header.LastCopied, "/bucket/a"), Equals, true)
+
+	// A resumed session rotates the failed object.
+	handler.denied = nil
+	c.Assert(doRotateSession(session), IsNil)
+	c.Assert(handler.keys, DeepEquals, map[string]string{"a": keyMD5(newKey), "b": keyMD5(newKey), "c": keyMD5(newKey)})
+}
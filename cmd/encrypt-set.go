@@ -0,0 +1,84 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set the default encryption of a bucket",
+	Action: mainEncryptSet,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} sse-s3 TARGET
+  {{.HelpName}} sse-kms [KMS-KEY-ID] TARGET
+
+  New objects of the bucket are encrypted with server managed keys (SSE-S3) or with
+  keys of a KMS (SSE-KMS). The default key of the KMS is used if KMS-KEY-ID is not given.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Encrypt new objects of a bucket with server managed keys.
+     $ {{.HelpName}} sse-s3 s3/mybucket
+
+  2. Encrypt new objects of a bucket with a key of the KMS.
+     $ {{.HelpName}} sse-kms my-minio-key myminio/mybucket
+`,
+}
+
+// checkEncryptSetSyntax - validate all the passed arguments
+func checkEncryptSetSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	switch {
+	case len(args) == 2 && (args.First() == encryptTypeSSES3 || args.First() == encryptTypeSSEKMS):
+	case len(args) == 3 && args.First() == encryptTypeSSEKMS:
+	default:
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+func mainEncryptSet(ctx *cli.Context) error {
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptSetSyntax(ctx)
+
+	args := ctx.Args()
+	urlStr := args.Get(len(args) - 1)
+	encryption := &bucketEncryption{Algorithm: encryptionSSES3}
+	if args.First() == encryptTypeSSEKMS {
+		encryption.Algorithm = encryptionSSEKMS
+		if len(args) == 3 {
+			encryption.KMSKeyID = args.Get(1)
+		}
+	}
+
+	err := newEncryptClient(urlStr).SetEncryption(encryption.Algorithm, encryption.KMSKeyID)
+	fatalIf(err, "Unable to set the default encryption of `"+urlStr+"`.")
+
+	printMsg(encryptMessage{op: "set", URL: urlStr, Encryption: encryption})
+	return nil
+}
@@ -0,0 +1,242 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Filter flags which may be repeated, they are stored in sessions
+// joined by new lines.
+var filterSliceFlagNames = []string{"include", "exclude", "include-regex", "exclude-regex"}
+
+// Filter flags which may not be repeated.
+var filterStringFlagNames = []string{"exclude-from", "larger", "smaller", "older-than", "newer-than"}
+
+// filterUnitsHelp is the UNITS section of the help of the commands
+// with the --larger and --smaller filter flags.
+const filterUnitsHelp = `UNITS:
+  --smaller, --larger flags accept human-readable case-insensitive number
+  suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
+  MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
+  units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
+  also accepted. Without suffixes the unit is bytes.
+`
+
+// getFilterFlags returns the filter flags passed on the command line,
+// in the form they are stored in sessions.
+func getFilterFlags(ctx *cli.Context) map[string]string {
+	flags := make(map[string]string)
+	for _, name := range filterSliceFlagNames {
+		flags[name] = strings.Join(ctx.StringSlice(name), "\n")
+	}
+	for _, name := range filterStringFlagNames {
+		flags[name] = ctx.String(name)
+	}
+	// Sessions are resumed from their root path.
+	if excludeFrom := flags["exclude-from"]; excludeFrom != "" {
+		if absPath, e := filepath.Abs(excludeFrom); e == nil {
+			flags["exclude-from"] = absPath
+		}
+	}
+	return flags
+}
+
+// setFilterFlags copies the filter flags into session flags.
+func setFilterFlags(sessionFlags, flags map[string]string) {
+	for _, name := range append(filterSliceFlagNames, filterStringFlagNames...) {
+		sessionFlags[name] = flags[name]
+	}
+}
+
+// objectFilter selects objects by name, size and age. Names are
+// matched relative to the walked prefix, an object is selected if it
+// matches any include pattern, when there are some, and no exclude
+// pattern. A nil filter selects all objects.
+type objectFilter struct {
+	include, exclude           []string
+	includeRegex, excludeRegex []*regexp.Regexp
+	larger, smaller            uint64
+	olderThan, newerThan       string
+}
+
+// splitFilterFlag splits a repeated filter flag as stored in sessions.
+func splitFilterFlag(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, "\n")
+}
+
+// readFilterFile reads patterns from a file, one per line, empty lines
+// and lines starting with '#' are ignored.
+func readFilterFile(filterFile string) ([]string, *probe.Error) {
+	f, e := os.Open(filterFile)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filterFile)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(filterFile)
+	}
+	return patterns, nil
+}
+
+// compileFilterRegexes compiles all regex patterns.
+func compileFilterRegexes(patterns []string) ([]*regexp.Regexp, *probe.Error) {
+	var regexes []*regexp.Regexp
+	for _, pattern := range patterns {
+		regex, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(pattern)
+		}
+		regexes = append(regexes, regex)
+	}
+	return regexes, nil
+}
+
+// newObjectFilter returns the filter described by flags, as returned
+// by getFilterFlags, or nil if no filter is set.
+func newObjectFilter(flags map[string]string) (*objectFilter, *probe.Error) {
+	f := &objectFilter{
+		include:   splitFilterFlag(flags["include"]),
+		exclude:   splitFilterFlag(flags["exclude"]),
+		olderThan: flags["older-than"],
+		newerThan: flags["newer-than"],
+	}
+
+	var err *probe.Error
+	if f.includeRegex, err = compileFilterRegexes(splitFilterFlag(flags["include-regex"])); err != nil {
+		return nil, err.Trace()
+	}
+	if f.excludeRegex, err = compileFilterRegexes(splitFilterFlag(flags["exclude-regex"])); err != nil {
+		return nil, err.Trace()
+	}
+	if excludeFrom := flags["exclude-from"]; excludeFrom != "" {
+		patterns, err := readFilterFile(excludeFrom)
+		if err != nil {
+			return nil, err.Trace(excludeFrom)
+		}
+		f.exclude = append(f.exclude, patterns...)
+	}
+
+	var e error
+	if larger := flags["larger"]; larger != "" {
+		if f.larger, e = humanize.ParseBytes(larger); e != nil {
+			return nil, probe.NewError(e).Trace(larger)
+		}
+	}
+	if smaller := flags["smaller"]; smaller != "" {
+		if f.smaller, e = humanize.ParseBytes(smaller); e != nil {
+			return nil, probe.NewError(e).Trace(smaller)
+		}
+	}
+	for _, age := range []string{f.olderThan, f.newerThan} {
+		if age == "" {
+			continue
+		}
+		if _, e = ioutils.ParseDurationTime(age); e != nil {
+			return nil, probe.NewError(e).Trace(age)
+		}
+	}
+
+	if len(f.include) == 0 && len(f.exclude) == 0 && len(f.includeRegex) == 0 && len(f.excludeRegex) == 0 &&
+		f.larger == 0 && f.smaller == 0 && f.olderThan == "" && f.newerThan == "" {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// matchName reports whether an object name, relative to the walked
+// prefix, is selected by the include and exclude patterns.
+func (f *objectFilter) matchName(name string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 || len(f.includeRegex) > 0 {
+		included := matchExcludeOptions(f.include, name)
+		for _, regex := range f.includeRegex {
+			if included {
+				break
+			}
+			included = regex.MatchString(name)
+		}
+		if !included {
+			return false
+		}
+	}
+	if matchExcludeOptions(f.exclude, name) {
+		return false
+	}
+	for _, regex := range f.excludeRegex {
+		if regex.MatchString(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether an object is selected by all filters.
+func (f *objectFilter) match(name string, size int64, modTime time.Time) bool {
+	if f == nil {
+		return true
+	}
+	if !f.matchName(name) {
+		return false
+	}
+	if f.larger > 0 && size <= int64(f.larger) {
+		return false
+	}
+	if f.smaller > 0 && size >= int64(f.smaller) {
+		return false
+	}
+	// Skip objects older than --older-than parameter if specified
+	if f.olderThan != "" && isOlder(modTime, f.olderThan) {
+		return false
+	}
+	// Skip objects newer than --newer-than parameter if specified
+	if f.newerThan != "" && isNewer(modTime, f.newerThan) {
+		return false
+	}
+	return true
+}
+
+// filterName returns the name of an object relative to the walked
+// prefix, as matched by filters.
+func filterName(prefix, objectPath string) string {
+	return strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(objectPath, prefix)), "/")
+}
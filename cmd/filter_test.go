@@ -0,0 +1,163 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestObjectFilterMatchName(t *testing.T) {
+	testCases := []struct {
+		flags map[string]string
+		name  string
+		match bool
+	}{
+		// No filter selects everything.
+		{map[string]string{}, "a/b.txt", true},
+		{map[string]string{"include": "*.txt"}, "a/b.txt", true},
+		{map[string]string{"include": "*.txt"}, "a/b.jpg", false},
+		{map[string]string{"include": "*.txt\n*.jpg"}, "a/b.jpg", true},
+		{map[string]string{"exclude": "a/*"}, "a/b.txt", false},
+		{map[string]string{"exclude": "a/*"}, "c/b.txt", true},
+		// Exclude patterns win over include patterns.
+		{map[string]string{"include": "*.txt", "exclude": "a/*"}, "a/b.txt", false},
+		{map[string]string{"include-regex": `^logs/\d+\.log$`}, "logs/12.log", true},
+		{map[string]string{"include-regex": `^logs/\d+\.log$`}, "logs/x.log", false},
+		// Names matching either an include pattern or an include regex are selected.
+		{map[string]string{"include": "*.txt", "include-regex": `\.log$`}, "x.log", true},
+		{map[string]string{"exclude-regex": `\.tmp$`}, "x.tmp", false},
+		{map[string]string{"exclude-regex": `\.tmp$`}, "x.tmpl", true},
+	}
+
+	for i, testCase := range testCases {
+		filter, err := newObjectFilter(testCase.flags)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if match := filter.matchName(testCase.name); match != testCase.match {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.match, match)
+		}
+	}
+}
+
+func TestObjectFilterMatch(t *testing.T) {
+	now := UTCNow()
+	testCases := []struct {
+		flags   map[string]string
+		size    int64
+		modTime time.Time
+		match   bool
+	}{
+		{map[string]string{"larger": "1KiB"}, 1025, now, true},
+		{map[string]string{"larger": "1KiB"}, 1024, now, false},
+		{map[string]string{"smaller": "1KiB"}, 1023, now, true},
+		{map[string]string{"smaller": "1KiB"}, 1024, now, false},
+		{map[string]string{"larger": "1KiB", "smaller": "1MiB"}, 4096, now, true},
+		{map[string]string{"older-than": "1d"}, 0, now.Add(-48 * time.Hour), true},
+		{map[string]string{"older-than": "1d"}, 0, now.Add(-time.Hour), false},
+		{map[string]string{"newer-than": "1d"}, 0, now.Add(-time.Hour), true},
+		{map[string]string{"newer-than": "1d"}, 0, now.Add(-48 * time.Hour), false},
+		// Name filters apply as well.
+		{map[string]string{"exclude": "*.txt", "larger": "1"}, 10, now, false},
+	}
+
+	for i, testCase := range testCases {
+		filter, err := newObjectFilter(testCase.flags)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if match := filter.match("a.txt", testCase.size, testCase.modTime); match != testCase.match {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.match, match)
+		}
+	}
+}
+
+func TestNewObjectFilter(t *testing.T) {
+	filter, err := newObjectFilter(map[string]string{})
+	if err != nil || filter != nil {
+		t.Fatalf("expected no filter, got %v, %v", filter, err)
+	}
+
+	invalidFlags := []map[string]string{
+		{"include-regex": "("},
+		{"exclude-regex": "[a-"},
+		{"larger": "10XB"},
+		{"smaller": "-1"},
+		{"older-than": "7x"},
+		{"exclude-from": "/non/existent/filter/file"},
+	}
+	for i, flags := range invalidFlags {
+		if _, err = newObjectFilter(flags); err == nil {
+			t.Errorf("Test %d: expected an error for %v", i+1, flags)
+		}
+	}
+}
+
+func TestReadFilterFile(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-filter-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	filterFile := filepath.Join(dir, "exclude.lst")
+	content := "# temporary files\n*.tmp\n\n  cache/*  \n#*.log\n"
+	if e = ioutil.WriteFile(filterFile, []byte(content), 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	patterns, err := readFilterFile(filterFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"*.tmp", "cache/*"}; !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("expected %v, got %v", expected, patterns)
+	}
+
+	filter, err := newObjectFilter(map[string]string{"exclude": "*.bak", "exclude-from": filterFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"x.bak", "x.tmp", "cache/y"} {
+		if filter.matchName(name) {
+			t.Errorf("expected %s to be excluded", name)
+		}
+	}
+	if !filter.matchName("x.log") {
+		t.Errorf("expected x.log to be selected")
+	}
+}
+
+func TestFilterName(t *testing.T) {
+	testCases := []struct {
+		prefix, objectPath, name string
+	}{
+		{"/bucket/dir/", "/bucket/dir/a/b.txt", "a/b.txt"},
+		{"/bucket/dir", "/bucket/dir/a/b.txt", "a/b.txt"},
+		{"/bucket/dir/", "/bucket/other/b.txt", "bucket/other/b.txt"},
+	}
+	for i, testCase := range testCases {
+		if name := filterName(testCase.prefix, testCase.objectPath); name != testCase.name {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.name, name)
+		}
+	}
+}
@@ -85,7 +85,7 @@ var findCmd = cli.Command{
 	Usage:  "search for objects",
 	Action: mainFind,
 	Before: setGlobalsFromContext,
-	Flags:  append(findFlags, globalFlags...),
+	Flags:  append(append(findFlags, filterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -150,6 +150,9 @@ EXAMPLES:
 
   10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
       $ {{.HelpName}} s3/bucket --maxdepth 3
+
+  11. Find all objects under "s3/bucket", excluding the object names matching the patterns listed in a file.
+      $ {{.HelpName}} s3/bucket --exclude-from find.exclude
 `,
 }
 
@@ -198,6 +201,7 @@ type findContext struct {
 	largerSize    uint64
 	smallerSize   uint64
 	watch         bool
+	filter        *objectFilter
 
 	// Internal values
 	targetAlias   string
@@ -252,6 +256,9 @@ func mainFind(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(ctx.String("smaller")), "Unable to parse input bytes.")
 	}
 
+	filter, err := newObjectFilter(getFilterFlags(ctx))
+	fatalIf(err, "Unable to parse filters.")
+
 	targetAlias, _, hostCfg, err := expandAlias(args[0])
 	fatalIf(err.Trace(args[0]), "Unable to expand alias.")
 
@@ -274,6 +281,7 @@ func mainFind(ctx *cli.Context) error {
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
 		watch:         ctx.Bool("watch"),
+		filter:        filter,
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
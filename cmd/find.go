@@ -396,6 +396,9 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && ctx.regexPattern != "" {
 		match = regexMatch(ctx.regexPattern, path)
 	}
+	if match {
+		match = ctx.filter.matchName(path)
+	}
 	if match && ctx.olderThan != "" {
 		match = !isOlder(fileContent.Time, ctx.olderThan)
 	}
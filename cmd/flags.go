@@ -64,6 +64,100 @@ var ioFlags = []cli.Flag{
 	},
 }
 
+// Flags common across commands transferring object data such as cp and mirror.
+var limitFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "limit-upload",
+		Usage: "limit upload bandwidth shared by all transfers, e.g. '10MiB/s'",
+	},
+	cli.StringFlag{
+		Name:  "limit-download",
+		Usage: "limit download bandwidth shared by all transfers, e.g. '10MiB/s'",
+	},
+}
+
+// Flags common across commands writing a transfer report such as cp, mirror and rm.
+var reportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "report",
+		Usage: "write the outcome of every object to a JSON or CSV (.csv) report file",
+	},
+}
+
+// Flags controlling retries of failed transfers of commands such as cp and mirror.
+var retryFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "retry",
+		Value: defaultMaxRetries,
+		Usage: "number of times to retry transfers failing with transient errors, with a backoff",
+	},
+}
+
+// Flags controlling the number of parallel transfers of commands such as cp and mirror.
+var parallelFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "parallel",
+		Usage: "fixed number of parallel transfers, disables auto-scaling",
+	},
+	cli.IntFlag{
+		Name:  "min-parallel",
+		Usage: "number of parallel transfers to start with (default: number of CPUs)",
+	},
+	cli.IntFlag{
+		Name:  "max-parallel",
+		Usage: "maximum number of parallel transfers when auto-scaling (default: 128)",
+	},
+}
+
+// Flags selecting objects by name, common across commands walking
+// objects such as cp, mirror, rm, find and du.
+var filterFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "include",
+		Usage: "include only object(s) that match specified object name pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "exclude object(s) that match specified object name pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "include-regex",
+		Usage: "include only object(s) that match specified object name regex",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude-regex",
+		Usage: "exclude object(s) that match specified object name regex",
+	},
+	cli.StringFlag{
+		Name:  "exclude-from",
+		Usage: "exclude object(s) that match object name patterns read from a file, one per line",
+	},
+}
+
+// Flags selecting objects by size, common across commands walking objects.
+var sizeFilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "larger",
+		Usage: "match all objects larger than specified size in units (see UNITS)",
+	},
+	cli.StringFlag{
+		Name:  "smaller",
+		Usage: "match all objects smaller than specified size in units (see UNITS)",
+	},
+}
+
+// Flags selecting objects by age, common across commands walking objects.
+var ageFilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "match all objects older than L days, M hours and N minutes",
+	},
+	cli.StringFlag{
+		Name:  "newer-than",
+		Usage: "match all objects newer than L days, M hours and N minutes",
+	},
+}
+
 // registerCmd registers a cli command
 func registerCmd(cmd cli.Command) {
 	commands = append(commands, cmd)
@@ -68,6 +68,13 @@ var (
 
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
+
+	// Bandwidth limiters shared by all transfers, a nil value means unlimited
+	globalLimitUpload   *bandwidthLimiter
+	globalLimitDownload *bandwidthLimiter
+
+	// Report of the outcome of every object, a nil value means no report
+	globalTransferReport *transferReport
 )
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmAddFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "id of the rule, a random id is used if not set; an existing rule with the same id is replaced",
+		},
+		cli.StringFlag{
+			Name:  "prefix",
+			Usage: "apply the rule to objects with this prefix",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "apply the rule to objects with these tags, e.g. 'key1=value1&key2=value2'",
+		},
+		cli.IntFlag{
+			Name:  "expiry-days",
+			Usage: "expire objects this number of days after their creation",
+		},
+		cli.StringFlag{
+			Name:  "expiry-date",
+			Usage: "expire objects at this date, e.g. '2020-01-31'",
+		},
+		cli.IntFlag{
+			Name:  "transition-days",
+			Usage: "transition objects this number of days after their creation",
+		},
+		cli.StringFlag{
+			Name:  "transition-date",
+			Usage: "transition objects at this date, e.g. '2020-01-31'",
+		},
+		cli.StringFlag{
+			Name:  "storage-class",
+			Usage: "storage class objects are transitioned to",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "add the rule disabled",
+		},
+	}
+)
+
+var ilmAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "add or replace a bucket lifecycle rule",
+	Action: mainILMAdd,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmAddFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Expire objects under 'logs/' 90 days after their creation.
+     $ {{.HelpName}} --id expire-logs --prefix "logs/" --expiry-days 90 s3/mybucket
+
+  2. Transition objects tagged 'archive=true' to GLACIER after 30 days and expire them after a year.
+     $ {{.HelpName}} --tags "archive=true" --transition-days 30 --storage-class GLACIER --expiry-days 365 s3/mybucket
+
+  3. Expire objects under 'tmp/' at a date.
+     $ {{.HelpName}} --prefix "tmp/" --expiry-date "2020-01-31" s3/mybucket
+`,
+}
+
+// checkILMAddSyntax - validate all the passed arguments
+func checkILMAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+	}
+}
+
+func mainILMAdd(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	checkILMAddSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	rule, err := ilmOptions{
+		ID:             ctx.String("id"),
+		Prefix:         ctx.String("prefix"),
+		Tags:           ctx.String("tags"),
+		ExpiryDays:     ctx.Int("expiry-days"),
+		ExpiryDate:     ctx.String("expiry-date"),
+		TransitionDays: ctx.Int("transition-days"),
+		TransitionDate: ctx.String("transition-date"),
+		StorageClass:   ctx.String("storage-class"),
+		Disable:        ctx.Bool("disable"),
+	}.toRule()
+	fatalIf(err, "Invalid lifecycle rule.")
+
+	op := "add"
+	lifecycleRules.update(urlStr, func(rules bucketRules) *probe.Error {
+		if rules.(*lifecycleConfiguration).addRule(rule) {
+			op = "update"
+		}
+		return nil
+	})
+
+	printMsg(ilmMessage{op: op, Target: urlStr, ID: rule.ID, Rule: &rule})
+	return nil
+}
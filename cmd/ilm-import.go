@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var ilmImportCmd = cli.Command{
+	Name:   "import",
+	Usage:  "import bucket lifecycle rules from JSON or XML",
+	Action: mainILMImport,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [FILE]
+
+  The lifecycle configuration is read from STDIN if FILE is not given. It replaces
+  all existing lifecycle rules of the bucket.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Import the lifecycle rules exported from another bucket.
+     $ {{.HelpName}} s3/mybucket lifecycle.json
+
+  2. Copy the lifecycle rules of a bucket to another bucket.
+     $ mc ilm export s3/mybucket | {{.HelpName}} myminio/mybucket
+`,
+}
+
+func mainILMImport(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	lifecycleRules.importRules(ctx)
+	return nil
+}
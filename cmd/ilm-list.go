@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	ilmListFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "expiry",
+			Usage: "list only rules with an expiration",
+		},
+		cli.BoolFlag{
+			Name:  "transition",
+			Usage: "list only rules with a transition",
+		},
+	}
+)
+
+var ilmListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list bucket lifecycle rules",
+	Action: mainILMList,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmListFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the lifecycle rules of a bucket.
+     $ {{.HelpName}} s3/mybucket
+
+  2. List the lifecycle rules of a bucket which transition objects to another storage class.
+     $ {{.HelpName}} --transition s3/mybucket
+`,
+}
+
+// checkILMListSyntax - validate all the passed arguments
+func checkILMListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+func mainILMList(ctx *cli.Context) error {
+	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	checkILMListSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	onlyExpiry := ctx.Bool("expiry")
+	onlyTransition := ctx.Bool("transition")
+
+	lifecycle := lifecycleRules.fetch(urlStr).(*lifecycleConfiguration)
+	if len(lifecycle.Rules) == 0 {
+		if !globalJSON {
+			console.Println(lifecycleRules.opString("empty", urlStr, ""))
+		}
+		return nil
+	}
+
+	if !globalJSON {
+		ilmTable.printHeaders()
+	}
+	for i := range lifecycle.Rules {
+		rule := lifecycle.Rules[i]
+		if onlyExpiry && rule.Expiration == nil {
+			continue
+		}
+		if onlyTransition && len(rule.Transitions) == 0 {
+			continue
+		}
+		printMsg(ilmMessage{op: "list", Target: urlStr, ID: rule.ID, Rule: &rule})
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmFlags = []cli.Flag{}
+)
+
+var ilmCmd = cli.Command{
+	Name:            "ilm",
+	Usage:           "manage bucket lifecycle rules",
+	HideHelpCommand: true,
+	Action:          mainILM,
+	Before:          setGlobalsFromContext,
+	Flags:           append(ilmFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		ilmListCmd,
+		ilmAddCmd,
+		ilmRemoveCmd,
+		ilmExportCmd,
+		ilmImportCmd,
+	},
+}
+
+// mainILM is the handle for "mc ilm" command.
+func mainILM(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "list", "add", "remove" have their own main.
+}
+
+// lifecycleRules gets and sets the lifecycle rules of buckets.
+var lifecycleRules = bucketRulesKind{
+	name:  "lifecycle",
+	theme: "ILM",
+	get: func(c *s3Client) (bucketRules, *probe.Error) {
+		return c.GetLifecycle()
+	},
+	set: func(c *s3Client, rules bucketRules) *probe.Error {
+		lifecycle, _ := rules.(*lifecycleConfiguration)
+		return c.SetLifecycle(lifecycle)
+	},
+	parse: func(data []byte) (bucketRules, *probe.Error) {
+		return parseLifecycleConfiguration(data)
+	},
+	message: func(op, target, id string) message {
+		return ilmMessage{op: op, Target: target, ID: id}
+	},
+}
+
+// ilmMessage container for lifecycle rule messages.
+type ilmMessage struct {
+	op     string
+	Status string         `json:"status"`
+	Target string         `json:"target"`
+	ID     string         `json:"id,omitempty"`
+	Rule   *lifecycleRule `json:"rule,omitempty"`
+}
+
+// ilmTable lists lifecycle rules.
+var ilmTable = rulesTable{
+	{"ID", 20},
+	{"Prefix", 16},
+	{"Tags", 20},
+	{"Status", 8},
+	{"Expiry", 12},
+	{"Transition", -1},
+}
+
+func (u ilmMessage) String() string {
+	if u.op != "list" {
+		return lifecycleRules.opString(u.op, u.Target, u.ID)
+	}
+	var expiry string
+	if exp := u.Rule.Expiration; exp != nil && (exp.Days > 0 || exp.Date != "") {
+		expiry = ilmDays(exp.Days, exp.Date)
+	}
+	var transitions []string
+	for _, tr := range u.Rule.Transitions {
+		transitions = append(transitions, ilmDays(tr.Days, tr.Date)+" to "+tr.StorageClass)
+	}
+	return ilmTable.buildRow(u.Rule.ID, u.Rule.prefix(), tagsString(u.Rule.tags()), u.Rule.Status, expiry,
+		strings.Join(transitions, ", "))
+}
+
+func (u ilmMessage) JSON() string {
+	u.Status = "success"
+	ilmMessageJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(ilmMessageJSONBytes)
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	ilmRemoveFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "id of the rule to remove",
+		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "remove all lifecycle rules of the bucket",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "allow removing all lifecycle rules",
+		},
+	}
+)
+
+var ilmRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove a bucket lifecycle rule; '--all --force' removes all rules",
+	Action: mainILMRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the lifecycle rule 'expire-logs' of a bucket.
+     $ {{.HelpName}} --id expire-logs s3/mybucket
+
+  2. Remove all lifecycle rules of a bucket. --force flag is mandatory here
+     $ {{.HelpName}} --all --force s3/mybucket
+`,
+}
+
+func mainILMRemove(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	lifecycleRules.remove(ctx)
+	return nil
+}
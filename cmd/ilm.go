@@ -0,0 +1,345 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Lifecycle rule status values.
+const (
+	ilmStatusEnabled  = "Enabled"
+	ilmStatusDisabled = "Disabled"
+)
+
+// Dates of lifecycle rules are given as days, they are sent to the
+// server as midnight UTC.
+const (
+	ilmDateFormat       = "2006-01-02"
+	ilmServerDateFormat = "2006-01-02T15:04:05Z"
+)
+
+// lifecycleConfiguration is the lifecycle configuration of a bucket.
+type lifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"LifecycleConfiguration" json:"-"`
+	Rules   []lifecycleRule `xml:"Rule" json:"rules"`
+}
+
+// lifecycleRule is a lifecycle rule, it applies to the objects
+// selected by its filter.
+type lifecycleRule struct {
+	ID     string          `xml:"ID" json:"id"`
+	Status string          `xml:"Status" json:"status"`
+	Filter lifecycleFilter `xml:"Filter" json:"filter"`
+	// Prefix is the deprecated rule level prefix, it is moved to the
+	// filter when the configuration is read.
+	Prefix                         string                             `xml:"Prefix,omitempty" json:"-"`
+	Expiration                     *lifecycleExpiration               `xml:"Expiration,omitempty" json:"expiration,omitempty"`
+	Transitions                    []lifecycleTransition              `xml:"Transition,omitempty" json:"transitions,omitempty"`
+	NoncurrentVersionExpiration    *lifecycleNoncurrentExpiration     `xml:"NoncurrentVersionExpiration,omitempty" json:"noncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransitions   []lifecycleNoncurrentTransition    `xml:"NoncurrentVersionTransition,omitempty" json:"noncurrentVersionTransitions,omitempty"`
+	AbortIncompleteMultipartUpload *lifecycleAbortIncompleteMultipart `xml:"AbortIncompleteMultipartUpload,omitempty" json:"abortIncompleteMultipartUpload,omitempty"`
+	// Unknown holds the elements of the rule mc does not know about,
+	// they are sent back as is when the configuration is written.
+	Unknown []xmlElement `xml:",any" json:"-"`
+}
+
+// xmlElement is an XML element kept as is.
+type xmlElement struct {
+	XMLName xml.Name
+	Inner   string `xml:",innerxml"`
+}
+
+// lifecycleFilter selects objects by prefix and tags, And is used
+// when more than one criteria is set.
+type lifecycleFilter struct {
+	Prefix string        `xml:"Prefix,omitempty" json:"prefix,omitempty"`
+	Tag    *objectTag    `xml:"Tag,omitempty" json:"tag,omitempty"`
+	And    *lifecycleAnd `xml:"And,omitempty" json:"and,omitempty"`
+}
+
+// lifecycleAnd combines a prefix and several tags.
+type lifecycleAnd struct {
+	Prefix string      `xml:"Prefix,omitempty" json:"prefix,omitempty"`
+	Tags   []objectTag `xml:"Tag,omitempty" json:"tags,omitempty"`
+}
+
+// lifecycleExpiration expires objects after a number of days since
+// their creation or at a date, or removes delete markers left without
+// noncurrent versions.
+type lifecycleExpiration struct {
+	Days                      int    `xml:"Days,omitempty" json:"days,omitempty"`
+	Date                      string `xml:"Date,omitempty" json:"date,omitempty"`
+	ExpiredObjectDeleteMarker bool   `xml:"ExpiredObjectDeleteMarker,omitempty" json:"expiredObjectDeleteMarker,omitempty"`
+}
+
+// lifecycleTransition moves objects to another storage class after a
+// number of days since their creation or at a date.
+type lifecycleTransition struct {
+	Days         int    `xml:"Days,omitempty" json:"days,omitempty"`
+	Date         string `xml:"Date,omitempty" json:"date,omitempty"`
+	StorageClass string `xml:"StorageClass" json:"storageClass"`
+}
+
+// lifecycleNoncurrentExpiration expires versions a number of days
+// after they become noncurrent.
+type lifecycleNoncurrentExpiration struct {
+	NoncurrentDays int `xml:"NoncurrentDays" json:"noncurrentDays"`
+}
+
+// lifecycleNoncurrentTransition moves versions to another storage
+// class a number of days after they become noncurrent.
+type lifecycleNoncurrentTransition struct {
+	NoncurrentDays int    `xml:"NoncurrentDays" json:"noncurrentDays"`
+	StorageClass   string `xml:"StorageClass" json:"storageClass"`
+}
+
+// lifecycleAbortIncompleteMultipart aborts multipart uploads a number
+// of days after they are initiated.
+type lifecycleAbortIncompleteMultipart struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation" json:"daysAfterInitiation"`
+}
+
+// prefix returns the prefix of the objects selected by the rule.
+func (r lifecycleRule) prefix() string {
+	if r.Filter.And != nil {
+		return r.Filter.And.Prefix
+	}
+	if r.Filter.Prefix != "" {
+		return r.Filter.Prefix
+	}
+	return r.Prefix
+}
+
+// tags returns the tags of the objects selected by the rule.
+func (r lifecycleRule) tags() []objectTag {
+	if r.Filter.And != nil {
+		return r.Filter.And.Tags
+	}
+	if r.Filter.Tag != nil {
+		return []objectTag{*r.Filter.Tag}
+	}
+	return nil
+}
+
+// newLifecycleFilter returns the filter selecting objects with prefix
+// and all tags.
+func newLifecycleFilter(prefix string, tags []objectTag) lifecycleFilter {
+	switch {
+	case len(tags) == 0:
+		return lifecycleFilter{Prefix: prefix}
+	case len(tags) == 1 && prefix == "":
+		return lifecycleFilter{Tag: &tags[0]}
+	default:
+		return lifecycleFilter{And: &lifecycleAnd{Prefix: prefix, Tags: tags}}
+	}
+}
+
+// parseLifecycleDate parses a date given as YYYY-MM-DD.
+func parseLifecycleDate(dateStr string) (string, *probe.Error) {
+	date, e := time.Parse(ilmDateFormat, dateStr)
+	if e != nil {
+		return "", probe.NewError(fmt.Errorf("invalid date `%s`, dates must be of the form YYYY-MM-DD", dateStr))
+	}
+	return date.UTC().Format(ilmServerDateFormat), nil
+}
+
+// ilmOptions are the options given to create a lifecycle rule.
+type ilmOptions struct {
+	ID             string
+	Prefix         string
+	Tags           string
+	ExpiryDays     int
+	ExpiryDate     string
+	TransitionDays int
+	TransitionDate string
+	StorageClass   string
+	Disable        bool
+}
+
+// toRule creates a validated lifecycle rule, a random ID is used if
+// none is given.
+func (opts ilmOptions) toRule() (lifecycleRule, *probe.Error) {
+	rule := lifecycleRule{ID: opts.ID, Status: ilmStatusEnabled}
+	if rule.ID == "" {
+		rule.ID = newRandomID(20)
+	}
+	if opts.Disable {
+		rule.Status = ilmStatusDisabled
+	}
+
+	tags, err := parseTags(opts.Tags)
+	if err != nil {
+		return rule, err.Trace(opts.Tags)
+	}
+	rule.Filter = newLifecycleFilter(opts.Prefix, tags)
+
+	if opts.ExpiryDays != 0 || opts.ExpiryDate != "" {
+		rule.Expiration = &lifecycleExpiration{Days: opts.ExpiryDays}
+		if opts.ExpiryDate != "" {
+			if rule.Expiration.Date, err = parseLifecycleDate(opts.ExpiryDate); err != nil {
+				return rule, err.Trace(opts.ExpiryDate)
+			}
+		}
+	}
+	if opts.TransitionDays != 0 || opts.TransitionDate != "" || opts.StorageClass != "" {
+		transition := lifecycleTransition{Days: opts.TransitionDays, StorageClass: opts.StorageClass}
+		if opts.TransitionDate != "" {
+			if transition.Date, err = parseLifecycleDate(opts.TransitionDate); err != nil {
+				return rule, err.Trace(opts.TransitionDate)
+			}
+		}
+		rule.Transitions = []lifecycleTransition{transition}
+	}
+	return rule, validateLifecycleRule(rule)
+}
+
+// validateLifecycleRule verifies that a rule can be applied.
+func validateLifecycleRule(rule lifecycleRule) *probe.Error {
+	if rule.ID == "" || len(rule.ID) > 255 {
+		return probe.NewError(errors.New("rule ID must be between 1 and 255 characters long")).Trace(rule.ID)
+	}
+	if rule.Status != ilmStatusEnabled && rule.Status != ilmStatusDisabled {
+		return probe.NewError(fmt.Errorf("rule status must be `%s` or `%s`", ilmStatusEnabled, ilmStatusDisabled)).Trace(rule.ID, rule.Status)
+	}
+	if rule.Expiration == nil && len(rule.Transitions) == 0 && rule.NoncurrentVersionExpiration == nil &&
+		len(rule.NoncurrentVersionTransitions) == 0 && rule.AbortIncompleteMultipartUpload == nil {
+		return probe.NewError(errors.New("rule must set an expiration or a transition")).Trace(rule.ID)
+	}
+	if exp := rule.Expiration; exp != nil {
+		if exp.Days != 0 && exp.Date != "" || exp.Days == 0 && exp.Date == "" && !exp.ExpiredObjectDeleteMarker {
+			return probe.NewError(errors.New("expiration must set either days or a date")).Trace(rule.ID)
+		}
+		if exp.Days < 0 {
+			return probe.NewError(errors.New("expiration days must be positive")).Trace(rule.ID)
+		}
+	}
+	for _, tr := range rule.Transitions {
+		if (tr.Days == 0) == (tr.Date == "") {
+			return probe.NewError(errors.New("transition must set either days or a date")).Trace(rule.ID)
+		}
+		if tr.Days < 0 {
+			return probe.NewError(errors.New("transition days must be positive")).Trace(rule.ID)
+		}
+		if tr.StorageClass == "" {
+			return probe.NewError(errors.New("transition must set a storage class")).Trace(rule.ID)
+		}
+	}
+	if exp := rule.NoncurrentVersionExpiration; exp != nil && exp.NoncurrentDays <= 0 {
+		return probe.NewError(errors.New("noncurrent version expiration days must be positive")).Trace(rule.ID)
+	}
+	for _, tr := range rule.NoncurrentVersionTransitions {
+		if tr.NoncurrentDays <= 0 {
+			return probe.NewError(errors.New("noncurrent version transition days must be positive")).Trace(rule.ID)
+		}
+		if tr.StorageClass == "" {
+			return probe.NewError(errors.New("noncurrent version transition must set a storage class")).Trace(rule.ID)
+		}
+	}
+	if abort := rule.AbortIncompleteMultipartUpload; abort != nil && abort.DaysAfterInitiation <= 0 {
+		return probe.NewError(errors.New("days after initiation of incomplete multipart uploads must be positive")).Trace(rule.ID)
+	}
+	if exp := rule.Expiration; exp != nil && exp.Days > 0 {
+		for _, tr := range rule.Transitions {
+			if tr.Days > 0 && exp.Days <= tr.Days {
+				return probe.NewError(errors.New("expiration days must be greater than transition days")).Trace(rule.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// normalize moves the deprecated rule level prefixes to the filters.
+func (c *lifecycleConfiguration) normalize() {
+	for i := range c.Rules {
+		if c.Rules[i].Prefix != "" {
+			c.Rules[i].Filter = newLifecycleFilter(c.Rules[i].Prefix, c.Rules[i].tags())
+			c.Rules[i].Prefix = ""
+		}
+	}
+}
+
+// parseLifecycleConfiguration parses and validates a lifecycle
+// configuration in the JSON format of 'mc ilm export' or in the XML
+// format of the S3 API.
+func parseLifecycleConfiguration(data []byte) (*lifecycleConfiguration, *probe.Error) {
+	lifecycle := &lifecycleConfiguration{}
+	var e error
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		e = xml.Unmarshal(data, lifecycle)
+	} else {
+		e = json.Unmarshal(data, lifecycle)
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	lifecycle.normalize()
+
+	ids := make(map[string]bool)
+	for _, rule := range lifecycle.Rules {
+		if ids[rule.ID] {
+			return nil, probe.NewError(fmt.Errorf("duplicate rule ID `%s`", rule.ID))
+		}
+		ids[rule.ID] = true
+		if err := validateLifecycleRule(rule); err != nil {
+			return nil, err.Trace(rule.ID)
+		}
+	}
+	return lifecycle, nil
+}
+
+// addRule adds rule to the configuration, it replaces the rule with
+// the same ID if any.
+func (c *lifecycleConfiguration) addRule(rule lifecycleRule) (replaced bool) {
+	for i := range c.Rules {
+		if c.Rules[i].ID == rule.ID {
+			c.Rules[i] = rule
+			return true
+		}
+	}
+	c.Rules = append(c.Rules, rule)
+	return false
+}
+
+// removeRule removes the rule with ID from the configuration.
+func (c *lifecycleConfiguration) removeRule(id string) (found bool) {
+	for i := range c.Rules {
+		if c.Rules[i].ID == id {
+			c.Rules = append(c.Rules[:i], c.Rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ilmDays formats days or a date of a rule action.
+func ilmDays(days int, date string) string {
+	if days > 0 {
+		return fmt.Sprintf("%d days", days)
+	}
+	if t, e := time.Parse(ilmServerDateFormat, date); e == nil {
+		return t.Format(ilmDateFormat)
+	}
+	return date
+}
@@ -0,0 +1,269 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestILMOptionsToRule(t *testing.T) {
+	testCases := []struct {
+		opts    ilmOptions
+		success bool
+	}{
+		{ilmOptions{ID: "a", ExpiryDays: 30}, true},
+		{ilmOptions{ID: "a", ExpiryDate: "2020-01-31"}, true},
+		{ilmOptions{ID: "a", TransitionDays: 30, StorageClass: "GLACIER"}, true},
+		{ilmOptions{ID: "a", TransitionDays: 30, StorageClass: "GLACIER", ExpiryDays: 365}, true},
+		{ilmOptions{ID: "a", Prefix: "logs/", Tags: "k1=v1&k2=v2", ExpiryDays: 1}, true},
+		// No action.
+		{ilmOptions{ID: "a", Prefix: "logs/"}, false},
+		// Both days and date.
+		{ilmOptions{ID: "a", ExpiryDays: 30, ExpiryDate: "2020-01-31"}, false},
+		{ilmOptions{ID: "a", ExpiryDays: -1}, false},
+		{ilmOptions{ID: "a", ExpiryDate: "31/01/2020"}, false},
+		// Transition without storage class, or storage class without transition.
+		{ilmOptions{ID: "a", TransitionDays: 30}, false},
+		{ilmOptions{ID: "a", StorageClass: "GLACIER", ExpiryDays: 30}, false},
+		// Expiration before transition.
+		{ilmOptions{ID: "a", TransitionDays: 30, StorageClass: "GLACIER", ExpiryDays: 10}, false},
+		{ilmOptions{ID: "a", Tags: "k1=v1&k1=v2", ExpiryDays: 1}, false},
+	}
+
+	for i, testCase := range testCases {
+		_, err := testCase.opts.toRule()
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	rule, err := ilmOptions{Disable: true, ExpiryDate: "2020-01-31"}.toRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" || rule.Status != ilmStatusDisabled {
+		t.Errorf("expected a disabled rule with a random ID, got %+v", rule)
+	}
+	if rule.Expiration.Date != "2020-01-31T00:00:00Z" {
+		t.Errorf("unexpected expiration date %s", rule.Expiration.Date)
+	}
+}
+
+func TestLifecycleFilter(t *testing.T) {
+	testCases := []struct {
+		prefix string
+		tags   string
+		filter lifecycleFilter
+	}{
+		{"logs/", "", lifecycleFilter{Prefix: "logs/"}},
+		{"", "k=v", lifecycleFilter{Tag: &objectTag{"k", "v"}}},
+		{"logs/", "k=v", lifecycleFilter{And: &lifecycleAnd{Prefix: "logs/", Tags: []objectTag{{"k", "v"}}}}},
+		{"", "k2=v2&k1=v1", lifecycleFilter{And: &lifecycleAnd{Tags: []objectTag{{"k1", "v1"}, {"k2", "v2"}}}}},
+	}
+	for i, testCase := range testCases {
+		tags, err := parseTags(testCase.tags)
+		if err != nil {
+			t.Fatalf("Test %d: %s", i+1, err)
+		}
+		filter := newLifecycleFilter(testCase.prefix, tags)
+		if !reflect.DeepEqual(filter, testCase.filter) {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.filter, filter)
+		}
+		rule := lifecycleRule{Filter: filter}
+		if rule.prefix() != testCase.prefix || tagsString(rule.tags()) != tagsString(tags) {
+			t.Errorf("Test %d: unexpected prefix %s or tags %v", i+1, rule.prefix(), rule.tags())
+		}
+	}
+}
+
+func TestParseLifecycleConfiguration(t *testing.T) {
+	lifecycleXML := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ID>legacy</ID><Prefix>tmp/</Prefix><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule>
+  <Rule><ID>archive</ID><Filter><And><Prefix>data/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></And></Filter><Status>Disabled</Status>
+    <Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition></Rule>
+</LifecycleConfiguration>`
+
+	lifecycle, err := parseLifecycleConfiguration([]byte(lifecycleXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lifecycle.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(lifecycle.Rules))
+	}
+	// Legacy prefixes are moved to the filter.
+	if rule := lifecycle.Rules[0]; rule.Prefix != "" || rule.Filter.Prefix != "tmp/" {
+		t.Errorf("unexpected legacy rule %+v", rule)
+	}
+	if rule := lifecycle.Rules[1]; rule.prefix() != "data/" || tagsString(rule.tags()) != "k=v" || len(rule.Transitions) != 1 || rule.Transitions[0].StorageClass != "GLACIER" {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+
+	// Exported JSON is imported back as is.
+	lifecycleJSON, e := json.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	imported, err := parseLifecycleConfiguration(lifecycleJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported.Rules, lifecycle.Rules) {
+		t.Errorf("expected %+v, got %+v", lifecycle.Rules, imported.Rules)
+	}
+
+	// Rules are sent to the server with a filter.
+	lifecycleBytes, e := xml.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `<LifecycleConfiguration><Rule><ID>legacy</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><ID>archive</ID><Status>Disabled</Status><Filter><And><Prefix>data/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></And></Filter><Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition></Rule></LifecycleConfiguration>`
+	if string(lifecycleBytes) != expected {
+		t.Errorf("expected %s, got %s", expected, lifecycleBytes)
+	}
+
+	invalid := []string{
+		`{"rules": [{"id": "a", "status": "Enabled"}]}`,
+		`{"rules": [{"id": "a", "status": "On", "expiration": {"days": 1}}]}`,
+		`{"rules": [{"id": "a", "status": "Enabled", "expiration": {"days": 1}}, {"id": "a", "status": "Enabled", "expiration": {"days": 2}}]}`,
+		`{"rules": `,
+	}
+	for i, data := range invalid {
+		if _, err = parseLifecycleConfiguration([]byte(data)); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestLifecycleAddRemoveRule(t *testing.T) {
+	lifecycle := &lifecycleConfiguration{}
+	if lifecycle.addRule(lifecycleRule{ID: "a", Status: ilmStatusEnabled}) {
+		t.Errorf("expected rule a to be added")
+	}
+	lifecycle.addRule(lifecycleRule{ID: "b", Status: ilmStatusEnabled})
+	if !lifecycle.addRule(lifecycleRule{ID: "a", Status: ilmStatusDisabled}) {
+		t.Errorf("expected rule a to be replaced")
+	}
+	if len(lifecycle.Rules) != 2 || lifecycle.Rules[0].Status != ilmStatusDisabled {
+		t.Errorf("unexpected rules %+v", lifecycle.Rules)
+	}
+	if !lifecycle.removeRule("a") || lifecycle.removeRule("a") {
+		t.Errorf("expected rule a to be removed once")
+	}
+	if len(lifecycle.Rules) != 1 || lifecycle.Rules[0].ID != "b" {
+		t.Errorf("unexpected rules %+v", lifecycle.Rules)
+	}
+}
+
+func TestLifecycleRoundTrip(t *testing.T) {
+	lifecycleXML := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ID>versions</ID><Filter><Prefix>data/</Prefix></Filter><Status>Enabled</Status>
+    <Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration>
+    <NoncurrentVersionTransition><NoncurrentDays>30</NoncurrentDays><StorageClass>STANDARD_IA</StorageClass></NoncurrentVersionTransition>
+    <NoncurrentVersionTransition><NoncurrentDays>60</NoncurrentDays><StorageClass>GLACIER</StorageClass></NoncurrentVersionTransition>
+    <NoncurrentVersionExpiration><NoncurrentDays>90</NoncurrentDays></NoncurrentVersionExpiration>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+    <FutureAction><Days>3</Days></FutureAction></Rule>
+</LifecycleConfiguration>`
+
+	lifecycle, err := parseLifecycleConfiguration([]byte(lifecycleXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := lifecycle.Rules[0]
+	if !rule.Expiration.ExpiredObjectDeleteMarker || rule.NoncurrentVersionExpiration.NoncurrentDays != 90 ||
+		len(rule.NoncurrentVersionTransitions) != 2 || rule.AbortIncompleteMultipartUpload.DaysAfterInitiation != 7 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+
+	// Adding a rule keeps all the elements of the existing ones.
+	lifecycle.addRule(lifecycleRule{ID: "new", Status: ilmStatusEnabled, Expiration: &lifecycleExpiration{Days: 1}})
+	lifecycleBytes, e := xml.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `<LifecycleConfiguration><Rule><ID>versions</ID><Status>Enabled</Status><Filter><Prefix>data/</Prefix></Filter>` +
+		`<Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration>` +
+		`<NoncurrentVersionExpiration><NoncurrentDays>90</NoncurrentDays></NoncurrentVersionExpiration>` +
+		`<NoncurrentVersionTransition><NoncurrentDays>30</NoncurrentDays><StorageClass>STANDARD_IA</StorageClass></NoncurrentVersionTransition>` +
+		`<NoncurrentVersionTransition><NoncurrentDays>60</NoncurrentDays><StorageClass>GLACIER</StorageClass></NoncurrentVersionTransition>` +
+		`<AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>` +
+		`<FutureAction xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Days>3</Days></FutureAction></Rule>` +
+		`<Rule><ID>new</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`
+	if string(lifecycleBytes) != expected {
+		t.Errorf("expected %s, got %s", expected, lifecycleBytes)
+	}
+
+	// Known elements are exported to JSON and imported back.
+	lifecycleJSON, e := json.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	imported, err := parseLifecycleConfiguration(lifecycleJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule.Unknown = nil
+	if !reflect.DeepEqual(imported.Rules[0], rule) {
+		t.Errorf("expected %+v, got %+v", rule, imported.Rules[0])
+	}
+}
+
+func TestLifecycleTransitionsRoundTrip(t *testing.T) {
+	lifecycleXML := `<LifecycleConfiguration><Rule><ID>tiers</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter>` +
+		`<Expiration><Days>365</Days></Expiration>` +
+		`<Transition><Days>30</Days><StorageClass>STANDARD_IA</StorageClass></Transition>` +
+		`<Transition><Days>90</Days><StorageClass>GLACIER</StorageClass></Transition></Rule></LifecycleConfiguration>`
+
+	lifecycle, err := parseLifecycleConfiguration([]byte(lifecycleXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedTransitions := []lifecycleTransition{{Days: 30, StorageClass: "STANDARD_IA"}, {Days: 90, StorageClass: "GLACIER"}}
+	if !reflect.DeepEqual(lifecycle.Rules[0].Transitions, expectedTransitions) {
+		t.Errorf("expected transitions %+v, got %+v", expectedTransitions, lifecycle.Rules[0].Transitions)
+	}
+
+	lifecycleBytes, e := xml.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if string(lifecycleBytes) != lifecycleXML {
+		t.Errorf("expected %s, got %s", lifecycleXML, lifecycleBytes)
+	}
+
+	lifecycleJSON, e := json.Marshal(lifecycle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	imported, err := parseLifecycleConfiguration(lifecycleJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported.Rules[0].Transitions, expectedTransitions) {
+		t.Errorf("expected transitions %+v, got %+v", expectedTransitions, imported.Rules[0].Transitions)
+	}
+
+	// Every transition must happen before the expiration.
+	lifecycle.Rules[0].Expiration.Days = 60
+	if validateLifecycleRule(lifecycle.Rules[0]) == nil {
+		t.Errorf("expected an error for an expiration before the last transition")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	legalHoldClearFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "clear legal hold of all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "clear legal hold of objects",
+	Action: mainLegalHoldClear,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Clear legal hold of an object.
+     $ {{.HelpName}} s3/mylockedbucket/contracts/acme.pdf
+
+  2. Clear legal hold of all objects under a prefix.
+     $ {{.HelpName}} --recursive s3/mylockedbucket/contracts/
+`,
+}
+
+// checkLegalHoldClearSyntax - validate all the passed arguments
+func checkLegalHoldClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+func mainLegalHoldClear(ctx *cli.Context) error {
+	console.SetColor("LegalHold", color.New(color.FgGreen, color.Bold))
+
+	checkLegalHoldClearSyntax(ctx)
+
+	return setLegalHold(ctx, "clear", legalHoldOff)
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	legalHoldInfoFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "show legal hold of all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show legal hold of objects",
+	Action: mainLegalHoldInfo,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldInfoFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show legal hold of an object.
+     $ {{.HelpName}} s3/mylockedbucket/contracts/acme.pdf
+
+  2. Show legal hold of all objects under a prefix.
+     $ {{.HelpName}} --recursive s3/mylockedbucket/contracts/
+`,
+}
+
+// checkLegalHoldInfoSyntax - validate all the passed arguments
+func checkLegalHoldInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+func mainLegalHoldInfo(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.FgCyan, color.Bold))
+
+	checkLegalHoldInfoSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	return applyToObjects(urlStr, ctx.Bool("recursive"), "get legal hold of", func(clnt Client, objectURL string) *probe.Error {
+		status, err := clnt.GetLegalHold()
+		if err != nil {
+			return err
+		}
+		printMsg(legalHoldMessage{op: "info", URL: objectURL, LegalHold: status})
+		return nil
+	})
+}
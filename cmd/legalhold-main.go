@@ -0,0 +1,90 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	legalHoldFlags = []cli.Flag{}
+)
+
+var legalHoldCmd = cli.Command{
+	Name:            "legalhold",
+	Usage:           "manage legal hold of locked objects",
+	HideHelpCommand: true,
+	Action:          mainLegalHold,
+	Before:          setGlobalsFromContext,
+	Flags:           append(legalHoldFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		legalHoldSetCmd,
+		legalHoldClearCmd,
+		legalHoldInfoCmd,
+	},
+}
+
+// mainLegalHold is the handle for "mc legalhold" command.
+func mainLegalHold(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "clear", "info" have their own main.
+}
+
+// legalHoldMessage container for object legal hold messages.
+type legalHoldMessage struct {
+	op        string
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	LegalHold string `json:"legalHold"`
+}
+
+func (l legalHoldMessage) String() string {
+	switch l.op {
+	case "set":
+		return console.Colorize("LegalHold", fmt.Sprintf("Legal hold set on `%s`.", l.URL))
+	case "clear":
+		return console.Colorize("LegalHold", fmt.Sprintf("Legal hold cleared on `%s`.", l.URL))
+	case "info":
+		return fmt.Sprintf("%s: %s", console.Colorize("URL", l.URL), l.LegalHold)
+	}
+	return ""
+}
+
+func (l legalHoldMessage) JSON() string {
+	l.Status = "success"
+	legalHoldMessageJSONBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(legalHoldMessageJSONBytes)
+}
+
+// setLegalHold sets the legal hold status of the objects at urlStr.
+func setLegalHold(ctx *cli.Context, op, status string) error {
+	urlStr := ctx.Args().Get(0)
+	return applyToObjects(urlStr, ctx.Bool("recursive"), op+" legal hold of", func(clnt Client, objectURL string) *probe.Error {
+		if err := clnt.SetLegalHold(status); err != nil {
+			return err
+		}
+		printMsg(legalHoldMessage{op: op, URL: objectURL, LegalHold: status})
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	legalHoldSetFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "set legal hold of all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set legal hold of objects, they cannot be removed until it is cleared",
+	Action: mainLegalHoldSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Set legal hold of an object.
+     $ {{.HelpName}} s3/mylockedbucket/contracts/acme.pdf
+
+  2. Set legal hold of all objects under a prefix.
+     $ {{.HelpName}} --recursive s3/mylockedbucket/contracts/
+`,
+}
+
+// checkLegalHoldSetSyntax - validate all the passed arguments
+func checkLegalHoldSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+func mainLegalHoldSet(ctx *cli.Context) error {
+	console.SetColor("LegalHold", color.New(color.FgGreen, color.Bold))
+
+	checkLegalHoldSetSyntax(ctx)
+
+	return setLegalHold(ctx, "set", legalHoldOn)
+}
@@ -35,6 +35,30 @@ var (
 			Name:  "incomplete, I",
 			Usage: "list incomplete uploads",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "list all versions and delete markers of objects",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort by 'name', 'size' (largest first) or 'time' (newest first)",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the order of the listing",
+		},
+		cli.BoolFlag{
+			Name:  "summarize",
+			Usage: "print the total number and size of listed objects",
+		},
+		cli.BoolFlag{
+			Name:  "long, l",
+			Usage: "show storage class, ETag, content type and user metadata of objects",
+		},
+		cli.BoolFlag{
+			Name:  "raw",
+			Usage: "show sizes in bytes and times in RFC3339 format",
+		},
 	}
 )
 
@@ -44,7 +68,7 @@ var lsCmd = cli.Command{
 	Usage:  "list buckets and objects",
 	Action: mainList,
 	Before: setGlobalsFromContext,
-	Flags:  append(lsFlags, globalFlags...),
+	Flags:  append(append(lsFlags, ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -54,6 +78,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
 EXAMPLES:
   1. List buckets on Amazon S3 cloud storage.
      $ {{.HelpName}} s3
@@ -72,6 +99,24 @@ EXAMPLES:
 
   6. List incomplete (previously failed) uploads of objects on Amazon S3.
      $ {{.HelpName}} --incomplete s3/mybucket
+
+  7. List all versions and delete markers of objects in mybucket on Amazon S3.
+     $ {{.HelpName}} --versions --recursive s3/mybucket
+
+  8. List the 10 largest objects in mybucket on Amazon S3.
+     $ {{.HelpName}} --recursive --sort size s3/mybucket | head -10
+
+  9. List objects of mybucket on Amazon S3 from the oldest to the newest, with their total size.
+     $ {{.HelpName}} --recursive --sort time --reverse --summarize s3/mybucket
+
+  10. List objects of mybucket on Amazon S3 with their storage class, ETag, content type and user metadata.
+     $ {{.HelpName}} --long s3/mybucket
+
+  11. List objects of mybucket on Amazon S3 with sizes in bytes and RFC3339 times.
+     $ {{.HelpName}} --raw s3/mybucket
+
+  12. List objects of mybucket on Amazon S3 in the long format, with the key of its encrypted objects.
+     $ {{.HelpName}} --long --encrypt-key "s3/mybucket/=32byteslongsecretkeymustbegiven1" s3/mybucket
 `,
 }
 
@@ -89,8 +134,22 @@ func checkListSyntax(ctx *cli.Context) {
 	// extract URLs.
 	URLs := ctx.Args()
 	isIncomplete := ctx.Bool("incomplete")
+	isVersions := ctx.Bool("versions")
+	if isVersions && isIncomplete {
+		fatalIf(errInvalidArgument().Trace(URLs...), "Incomplete uploads cannot be listed with versions.")
+	}
+	switch sortBy := ctx.String("sort"); sortBy {
+	case "", lsSortName, lsSortSize, lsSortTime:
+	default:
+		fatalIf(errInvalidArgument().Trace(sortBy), "--sort must be one of '"+lsSortName+"', '"+lsSortSize+"' or '"+lsSortTime+"'.")
+	}
 
 	for _, url := range URLs {
+		if isVersions {
+			// Objects which only have delete markers left cannot
+			// be stat'ed, listing their versions reports errors.
+			continue
+		}
 		_, _, err := url2Stat(url, false, nil)
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
 			// Bucket name empty is a valid error for 'ls myminio',
@@ -110,13 +169,34 @@ func mainList(ctx *cli.Context) error {
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("VersionID", color.New(color.FgMagenta))
+	console.SetColor("Latest", color.New(color.FgGreen, color.Bold))
+	console.SetColor("DeleteMarker", color.New(color.FgRed, color.Bold))
+	console.SetColor("StorageClass", color.New(color.FgBlue))
+	console.SetColor("ETag", color.New(color.FgWhite))
+	console.SetColor("ContentType", color.New(color.FgWhite))
+	console.SetColor("Metadata", color.New(color.FgMagenta))
+	console.SetColor("Summary", color.New(color.Bold))
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
 
 	// check 'ls' cli arguments.
 	checkListSyntax(ctx)
 
 	// Set command flags from context.
-	isRecursive := ctx.Bool("recursive")
-	isIncomplete := ctx.Bool("incomplete")
+	opts := listOptions{
+		isRecursive:  ctx.Bool("recursive"),
+		isIncomplete: ctx.Bool("incomplete"),
+		isVersions:   ctx.Bool("versions"),
+		sortBy:       ctx.String("sort"),
+		reverse:      ctx.Bool("reverse"),
+		summarize:    ctx.Bool("summarize"),
+		long:         ctx.Bool("long"),
+		raw:          ctx.Bool("raw"),
+		encKeyDB:     encKeyDB,
+	}
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -131,7 +211,7 @@ func mainList(ctx *cli.Context) error {
 
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
 			var st *clientContent
-			st, err = clnt.Stat(isIncomplete, false, nil)
+			st, err = clnt.Stat(opts.isIncomplete, false, nil)
 			if err == nil && st.Type.IsDir() {
 				targetURL = targetURL + string(clnt.GetURL().Separator)
 				clnt, err = newClient(targetURL)
@@ -139,7 +219,8 @@ func mainList(ctx *cli.Context) error {
 			}
 		}
 
-		if e := doList(clnt, isRecursive, isIncomplete); e != nil {
+		targetAlias, _, _ := mustExpandAlias(targetURL)
+		if e := doList(clnt, targetAlias, opts); e != nil {
 			cErr = e
 		}
 	}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -42,18 +43,73 @@ type contentMessage struct {
 	Size     int64     `json:"size"`
 	Key      string    `json:"key"`
 	ETag     string    `json:"etag"`
+
+	VersionID      string `json:"versionId,omitempty"`
+	IsLatest       bool   `json:"isLatest,omitempty"`
+	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+
+	StorageClass string            `json:"storageClass,omitempty"`
+	ContentType  string            `json:"contentType,omitempty"`
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+
+	// Printing options of the listing.
+	long bool
+	raw  bool
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
-	message := console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(printDate)))
-	message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
+	var message string
+	if c.raw {
+		message = console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(time.RFC3339)))
+		message = message + console.Colorize("Size", fmt.Sprintf("%10d ", c.Size))
+	} else {
+		message = console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(printDate)))
+		message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
+	}
+	if c.VersionID != "" {
+		message = message + console.Colorize("VersionID", c.VersionID+" ")
+		if c.IsDeleteMarker {
+			message = message + console.Colorize("DeleteMarker", "DEL ")
+		} else {
+			message = message + console.Colorize("Latest", func() string {
+				if c.IsLatest {
+					return "LATEST "
+				}
+				return "       "
+			}())
+		}
+	}
+	if c.long {
+		// Missing values are shown as '-' to keep the number of columns.
+		orDash := func(s string) string {
+			if s == "" {
+				return "-"
+			}
+			return s
+		}
+		message = message + console.Colorize("StorageClass", fmt.Sprintf("%-8s ", orDash(c.StorageClass)))
+		message = message + console.Colorize("ETag", fmt.Sprintf("%-32s ", orDash(c.ETag)))
+		message = message + console.Colorize("ContentType", fmt.Sprintf("%-24s ", orDash(c.ContentType)))
+	}
 	message = func() string {
 		if c.Filetype == "folder" {
 			return message + console.Colorize("Dir", c.Key)
 		}
 		return message + console.Colorize("File", c.Key)
 	}()
+	if c.long && len(c.UserMetadata) > 0 {
+		var keys []string
+		for k := range c.UserMetadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var metadata []string
+		for _, k := range keys {
+			metadata = append(metadata, k+"="+c.UserMetadata[k])
+		}
+		message = message + " " + console.Colorize("Metadata", strings.Join(metadata, ","))
+	}
 	return message
 }
 
@@ -85,9 +141,101 @@ func parseContent(c *clientContent) contentMessage {
 	content.ETag = md5sum
 	// Convert OS Type to match console file printing style.
 	content.Key = getKey(c)
+	content.VersionID = c.VersionID
+	content.IsLatest = c.IsLatest
+	content.IsDeleteMarker = c.IsDeleteMarker
+	content.StorageClass = c.StorageClass
+	for k, v := range c.Metadata {
+		if strings.EqualFold(k, "Content-Type") {
+			content.ContentType = v
+		}
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			if content.UserMetadata == nil {
+				content.UserMetadata = make(map[string]string)
+			}
+			content.UserMetadata[k] = v
+		}
+	}
 	return content
 }
 
+// Sort orders supported by ls.
+const (
+	lsSortName = "name"
+	lsSortSize = "size"
+	lsSortTime = "time"
+)
+
+// listOptions of a listing.
+type listOptions struct {
+	isRecursive  bool
+	isIncomplete bool
+	isVersions   bool
+	// sortBy is one of lsSortName, lsSortSize or lsSortTime, or
+	// empty to keep the listing order.
+	sortBy    string
+	reverse   bool
+	summarize bool
+	long      bool
+	raw       bool
+	// encKeyDB holds the keys of the encrypted objects whose metadata
+	// is fetched by the long format.
+	encKeyDB map[string][]prefixSSEPair
+}
+
+// sortContents sorts a listing like ls(1): names in ascending order,
+// the largest and newest entries first. Reverse flips the order.
+func sortContents(contents []contentMessage, sortBy string, reverse bool) {
+	less := func(i, j int) bool {
+		switch sortBy {
+		case lsSortSize:
+			if contents[i].Size != contents[j].Size {
+				return contents[i].Size > contents[j].Size
+			}
+		case lsSortTime:
+			if !contents[i].Time.Equal(contents[j].Time) {
+				return contents[i].Time.After(contents[j].Time)
+			}
+		}
+		return contents[i].Key < contents[j].Key
+	}
+	if sortBy != "" {
+		sort.SliceStable(contents, less)
+	}
+	if reverse {
+		for i, j := 0, len(contents)-1; i < j; i, j = i+1, j-1 {
+			contents[i], contents[j] = contents[j], contents[i]
+		}
+	}
+}
+
+// listSummaryMessage container for the totals of a listing.
+type listSummaryMessage struct {
+	Status  string `json:"status"`
+	Objects int64  `json:"totalObjects"`
+	Size    int64  `json:"totalSize"`
+
+	raw bool
+}
+
+// String colorized listing totals.
+func (s listSummaryMessage) String() string {
+	size := humanize.IBytes(uint64(s.Size))
+	if s.raw {
+		size = fmt.Sprintf("%d", s.Size)
+	}
+	return console.Colorize("Summary", fmt.Sprintf("\nTotal Size: %s\nTotal Objects: %d", size, s.Objects))
+}
+
+// JSON jsonified listing totals.
+func (s listSummaryMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
 // get content key
 func getKey(c *clientContent) string {
 	sep := "/"
@@ -104,15 +252,73 @@ func getKey(c *clientContent) string {
 	return c.URL.Path
 }
 
-// doList - list all entities inside a folder.
-func doList(clnt Client, isRecursive, isIncomplete bool) error {
+// lsMetadataWorkers is the number of objects whose metadata is
+// fetched at the same time by the long format.
+const lsMetadataWorkers = 16
+
+// withMetadata returns the contents of contentCh in the same order,
+// fetching from targetAlias the metadata of the objects listed without
+// it. Objects whose metadata cannot be fetched are returned as listed.
+func withMetadata(contentCh <-chan *clientContent, targetAlias string, encKeyDB map[string][]prefixSSEPair) <-chan *clientContent {
+	// Each content is sent on its own channel once complete, these
+	// channels are read in the listing order.
+	pendingCh := make(chan chan *clientContent, lsMetadataWorkers)
+	go func() {
+		defer close(pendingCh)
+		for content := range contentCh {
+			doneCh := make(chan *clientContent, 1)
+			pendingCh <- doneCh
+			if content.Err != nil || content.Type.IsDir() || len(content.Metadata) > 0 {
+				doneCh <- content
+				continue
+			}
+			go func(content *clientContent) {
+				url := targetAlias + getKey(content)
+				if _, stat, err := url2Stat(url, true, encKeyDB); err == nil {
+					content.Metadata = stat.Metadata
+					if content.StorageClass == "" {
+						content.StorageClass = stat.StorageClass
+					}
+				}
+				doneCh <- content
+			}(content)
+		}
+	}()
+
+	resultCh := make(chan *clientContent)
+	go func() {
+		defer close(resultCh)
+		for doneCh := range pendingCh {
+			resultCh <- <-doneCh
+		}
+	}()
+	return resultCh
+}
+
+// doList - list all entities inside a folder, or all versions of
+// the objects inside a folder if opts.isVersions is set. The long
+// format fetches the metadata of the objects from targetAlias.
+func doList(clnt Client, targetAlias string, opts listOptions) error {
 	prefixPath := clnt.GetURL().Path
 	separator := string(clnt.GetURL().Separator)
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
+	contentCh := func() <-chan *clientContent {
+		if opts.isVersions {
+			return clnt.ListVersions(opts.isRecursive)
+		}
+		contentCh := clnt.List(opts.isRecursive, opts.isIncomplete, DirNone)
+		if opts.long && !opts.isIncomplete {
+			// Listings do not return the content type and user metadata.
+			return withMetadata(contentCh, targetAlias, opts.encKeyDB)
+		}
+		return contentCh
+	}()
 	var cErr error
-	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+	var contents []contentMessage
+	var summary listSummaryMessage
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -146,8 +352,26 @@ func doList(clnt Client, isRecursive, isIncomplete bool) error {
 		contentURL = strings.TrimPrefix(contentURL, prefixPath)
 		content.URL.Path = contentURL
 		parsedContent := parseContent(content)
+		parsedContent.long = opts.long
+		parsedContent.raw = opts.raw
+		if !content.Type.IsDir() && !content.IsDeleteMarker {
+			summary.Objects++
+			summary.Size += content.Size
+		}
+		if opts.sortBy != "" || opts.reverse {
+			contents = append(contents, parsedContent)
+			continue
+		}
 		// Print colorized or jsonized content info.
 		printMsg(parsedContent)
 	}
+	sortContents(contents, opts.sortBy, opts.reverse)
+	for _, content := range contents {
+		printMsg(content)
+	}
+	if opts.summarize {
+		summary.raw = opts.raw
+		printMsg(summary)
+	}
 	return cErr
 }
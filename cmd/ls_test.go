@@ -15,3 +15,141 @@
  */
 
 package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestSortContents(c *C) {
+	now := time.Now()
+	contents := []contentMessage{
+		{Key: "b", Size: 10, Time: now.Add(-time.Hour)},
+		{Key: "a", Size: 30, Time: now.Add(-2 * time.Hour)},
+		{Key: "c", Size: 20, Time: now},
+		{Key: "d", Size: 20, Time: now},
+	}
+	keys := func() (keys []string) {
+		for _, content := range contents {
+			keys = append(keys, content.Key)
+		}
+		return keys
+	}
+
+	sortContents(contents, lsSortName, false)
+	c.Assert(keys(), DeepEquals, []string{"a", "b", "c", "d"})
+	sortContents(contents, lsSortSize, false)
+	c.Assert(keys(), DeepEquals, []string{"a", "c", "d", "b"})
+	sortContents(contents, lsSortTime, false)
+	c.Assert(keys(), DeepEquals, []string{"c", "d", "b", "a"})
+	sortContents(contents, lsSortTime, true)
+	c.Assert(keys(), DeepEquals, []string{"a", "b", "d", "c"})
+	sortContents(contents, "", true)
+	c.Assert(keys(), DeepEquals, []string{"c", "d", "b", "a"})
+}
+
+func (s *TestSuite) TestParseContentMetadata(c *C) {
+	content := parseContent(&clientContent{
+		URL:          *newClientURL("https://play.min.io/bucket/object"),
+		Type:         0644,
+		StorageClass: "GLACIER",
+		Metadata: map[string]string{
+			"Content-Type":     "text/plain",
+			"Content-Length":   "12",
+			"X-Amz-Meta-Owner": "ops",
+		},
+	})
+	c.Assert(content.StorageClass, Equals, "GLACIER")
+	c.Assert(content.ContentType, Equals, "text/plain")
+	c.Assert(content.UserMetadata, DeepEquals, map[string]string{"X-Amz-Meta-Owner": "ops"})
+}
+
+// metadataHandler answers the HEAD requests of the long format of ls,
+// object "b" is encrypted with a customer key and "c" cannot be read.
+type metadataHandler struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (h *metadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	if r.Method == http.MethodGet {
+		key = r.URL.Query().Get("prefix")
+	}
+	h.mu.Lock()
+	h.keys = append(h.keys, key)
+	h.mu.Unlock()
+	if r.Method == http.MethodGet {
+		// Stat lists the object before sending a HEAD.
+		w.Write([]byte(`<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><Prefix>` + key +
+			`</Prefix><Contents><Key>` + key + `</Key><Size>0</Size></Contents></ListBucketResult>`))
+		return
+	}
+	switch r.URL.Path {
+	case "/bucket/a":
+		w.Header().Set("Content-Type", "text/plain")
+	case "/bucket/b":
+		if r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("X-Amz-Meta-Owner", "ops")
+	default:
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", "0")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *TestSuite) TestListWithMetadata(c *C) {
+	handler := &metadataHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV9, *probe.Error) { return newConfigV9(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+	os.Setenv("MC_HOST_lstest", strings.Replace(server.URL, "http://", "http://WLGDGYAQYIGI833EV05A:BYvgJM101sHngl2uzjXS@", 1))
+	defer os.Unsetenv("MC_HOST_lstest")
+
+	encKeyDB, err := parseAndValidateEncryptionKeys("lstest/bucket/b=32byteslongsecretkeymustbegiven1", "")
+	c.Assert(err, IsNil)
+
+	contentCh := make(chan *clientContent)
+	go func() {
+		defer close(contentCh)
+		for _, key := range []string{"a", "b", "c", "d"} {
+			content := &clientContent{URL: *newClientURL(server.URL + "/bucket/" + key), Type: 0644}
+			if key == "d" {
+				// Metadata returned by the listing is used as is.
+				content.Metadata = map[string]string{"Content-Type": "text/csv"}
+			}
+			contentCh <- content
+		}
+	}()
+
+	var keys, contentTypes []string
+	for content := range withMetadata(contentCh, "lstest", encKeyDB) {
+		keys = append(keys, content.URL.Path)
+		contentTypes = append(contentTypes, parseContent(content).ContentType)
+	}
+	// Contents keep the listing order, "c" is listed without metadata.
+	c.Assert(keys, DeepEquals, []string{"/bucket/a", "/bucket/b", "/bucket/c", "/bucket/d"})
+	c.Assert(contentTypes, DeepEquals, []string{"text/plain", "image/png", "", "text/csv"})
+	for _, key := range handler.keys {
+		c.Assert(key, Not(Equals), "d")
+	}
+}
@@ -309,6 +309,7 @@ var appCmds = []cli.Command{
 	mbCmd,
 	rbCmd,
 	cpCmd,
+	mvCmd,
 	mirrorCmd,
 	catCmd,
 	headCmd,
@@ -323,6 +324,13 @@ var appCmds = []cli.Command{
 	rmCmd,
 	eventCmd,
 	watchCmd,
+	ilmCmd,
+	replicateCmd,
+	corsCmd,
+	tagCmd,
+	retentionCmd,
+	legalHoldCmd,
+	encryptCmd,
 	policyCmd,
 	adminCmd,
 	sessionCmd,
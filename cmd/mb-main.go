@@ -35,6 +35,14 @@ var (
 			Name:  "ignore-existing, p",
 			Usage: "ignore if bucket/directory already exists",
 		},
+		cli.BoolFlag{
+			Name:  "with-lock, l",
+			Usage: "enable object lock on the bucket, it cannot be disabled later",
+		},
+		cli.BoolFlag{
+			Name:  "with-versioning",
+			Usage: "enable versioning on the new bucket",
+		},
 	}
 )
 
@@ -72,6 +80,12 @@ EXAMPLES:
 
   6. Ignore if bucket/directory already exists.
      $ {{.HelpName}} --ignore-existing myminio/mynewbucket
+
+  7. Create a new bucket with object lock enabled on Amazon S3 cloud storage.
+     $ {{.HelpName}} --with-lock s3/mylockedbucket
+
+  8. Create a new bucket with versioning enabled on Amazon S3 cloud storage.
+     $ {{.HelpName}} --with-versioning s3/myversionedbucket
 `,
 }
 
@@ -114,6 +128,8 @@ func mainMakeBucket(ctx *cli.Context) error {
 	// Save region.
 	region := ctx.String("region")
 	ignoreExisting := ctx.Bool("p")
+	withLock := ctx.Bool("with-lock")
+	withVersioning := ctx.Bool("with-versioning")
 
 	var cErr error
 	for _, targetURL := range ctx.Args() {
@@ -125,8 +141,16 @@ func mainMakeBucket(ctx *cli.Context) error {
 			continue
 		}
 
+		// Directories cannot be versioned, do not create them.
+		if withVersioning && clnt.GetURL().Type == fileSystem {
+			errorIf(probe.NewError(APINotImplemented{API: "SetVersioning", APIType: "filesystem"}).Trace(targetURL),
+				"Unable to enable versioning of `"+targetURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+
 		// Make bucket.
-		err = clnt.MakeBucket(region, ignoreExisting)
+		err = clnt.MakeBucket(region, ignoreExisting, withLock)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case BucketNameEmpty:
@@ -140,6 +164,14 @@ func mainMakeBucket(ctx *cli.Context) error {
 			continue
 		}
 
+		if withVersioning {
+			if err = clnt.SetVersioning(versioningEnabled); err != nil {
+				errorIf(err.Trace(targetURL), "Unable to enable versioning of `"+targetURL+"`.")
+				cErr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+		}
+
 		// Successfully created a bucket.
 		printMsg(makeBucketMessage{Status: "success", Bucket: targetURL})
 	}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -58,6 +60,10 @@ var (
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
 		},
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "compare object(s) of the same size by content (ETag/MD5) instead of time",
+		},
 		cli.StringFlag{
 			Name:  "region",
 			Usage: "specify region when creating new bucket(s) on target",
@@ -67,25 +73,17 @@ var (
 			Name:  "a",
 			Usage: "preserve bucket policy rules on target bucket(s)",
 		},
-		cli.StringSliceFlag{
-			Name:  "exclude",
-			Usage: "exclude object(s) that match specified object name pattern",
-		},
-		cli.StringFlag{
-			Name:  "older-than",
-			Usage: "filter object(s) older than L days, M hours and N minutes",
-		},
-		cli.StringFlag{
-			Name:  "newer-than",
-			Usage: "filter object(s) newer than L days, M hours and N minutes",
-		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "specify storage class for new object(s) on target",
 		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on new object(s) on target, e.g. 'key1=value1&key2=value2'",
+		},
 		cli.StringFlag{
 			Name:  "encrypt",
-			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
+			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys, 'prefix=kms:key-id' for SSE-KMS)",
 		},
 	}
 )
@@ -96,7 +94,7 @@ var mirrorCmd = cli.Command{
 	Usage:  "synchronize object(s) to a remote site",
 	Action: mainMirror,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(append(append(append(append(mirrorFlags, ioFlags...), filterFlags...), sizeFilterFlags...), ageFilterFlags...), limitFlags...), parallelFlags...), retryFlags...), reportFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -106,8 +104,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+` + filterUnitsHelp + `
 ENVIRONMENT VARIABLES:
-   MC_ENCRYPT:      list of comma delimited prefixes
+   MC_ENCRYPT:      list of comma delimited prefixes or prefix=kms:key-id values
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
 EXAMPLES:
@@ -150,6 +149,39 @@ EXAMPLES:
   12. Mirror server encrypted objects from MinIO cloud storage to a bucket on Amazon S3 cloud storage. In case the encryption key contains
       non-printable character like tab, pass the base64 encoded string as key.
       $ {{.HelpName}} --encrypt-key "s3/photos/=32byteslongsecretkeymustbegiven1,play/archive/=MzJieXRlc2xvbmdzZWNyZXRrZQltdXN0YmVnaXZlbjE=" s3/photos/ play/archive/
+
+  13. Mirror a local folder to Amazon S3 cloud storage, overwriting objects whose content differs even if
+      their size is the same and the local file is older.
+      $ {{.HelpName}} --checksum --overwrite ~/builds s3/artifacts/builds
+
+  14. Mirror a local folder to MinIO cloud storage, limiting the upload bandwidth shared by all parallel
+      transfers to 10MiB/s.
+      $ {{.HelpName}} --limit-upload 10MiB/s backup/ play/archive
+
+  15. Mirror a bucket from MinIO cloud storage to Amazon S3 cloud storage, starting with 2 parallel transfers
+      and adding more while the transfer speed increases, up to 8 parallel transfers.
+      $ {{.HelpName}} --min-parallel 2 --max-parallel 8 play/photos/2014 s3/backup-photos
+
+  16. Mirror a local folder to MinIO cloud storage without retrying failed transfers.
+      $ {{.HelpName}} --retry 0 backup/ play/archive
+
+  17. Resume an interrupted mirror from where it left off.
+      $ mc session resume ygVIpSJs
+
+  18. Mirror a bucket from MinIO cloud storage to Amazon S3 cloud storage and write a CSV report of every
+      copied, skipped, removed or failed object.
+      $ {{.HelpName}} --remove --report migration.csv play/photos/2014 s3/backup-photos
+
+  19. Mirror a local folder to MinIO cloud storage, excluding object names matching the patterns listed in a file
+      and objects with names matching a regex.
+      $ {{.HelpName}} --exclude-from backup.exclude --exclude-regex "\.(tmp|swp)$" backup/ play/archive
+
+  20. Mirror a local folder to Amazon S3 cloud storage and tag the uploaded objects.
+      $ {{.HelpName}} --tags "project=backup&owner=ops" backup/ s3/archive
+
+  21. Mirror a local folder to Amazon S3 cloud storage, encrypting objects with the KMS key 'my-key-id' and an
+      encryption context.
+      $ {{.HelpName}} --encrypt "s3/archive=kms:my-key-id?department=finance" backup/ s3/archive
 `,
 }
 
@@ -182,11 +214,16 @@ type mirrorJob struct {
 	targetURL string
 
 	isFake, isRemove, isOverwrite, isWatch bool
-	olderThan, newerThan                   string
+	isChecksum                             bool
 	storageClass                           string
+	tags                                   string
+	maxRetries                             int
+
+	filter   *objectFilter
+	encKeyDB map[string][]prefixSSEPair
 
-	excludeOptions []string
-	encKeyDB       map[string][]prefixSSEPair
+	// Session recording the progress of the mirror.
+	session *sessionV8
 }
 
 // mirrorMessage container for file mirror messages
@@ -214,8 +251,15 @@ func (m mirrorMessage) JSON() string {
 }
 
 // doRemove - removes files on target.
-func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
+func (mj *mirrorJob) doRemove(sURLs URLs) (status URLs) {
+	start := UTCNow()
+	reportStatus := reportRemoved
+	defer func() {
+		globalTransferReport.addURLs(reportStatus, status, time.Since(start))
+	}()
+
 	if mj.isFake {
+		reportStatus = reportSkipped
 		return sURLs.WithError(nil)
 	}
 
@@ -246,7 +290,12 @@ func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
 }
 
 // doMirror - Mirror an object to multiple destination. URLs status contains a copy of sURLs and error if any.
-func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFunc, sURLs URLs) URLs {
+func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFunc, sURLs URLs) (status URLs) {
+	start := UTCNow()
+	reportStatus := reportCopied
+	defer func() {
+		globalTransferReport.addURLs(reportStatus, status, time.Since(start))
+	}()
 
 	if sURLs.Error != nil { // Erroneous sURLs passed.
 		return sURLs.WithError(sURLs.Error.Trace())
@@ -256,6 +305,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 	// and accounting readers under relevant conditions.
 	if mj.isFake {
 		mj.status.Add(sURLs.SourceContent.Size)
+		reportStatus = reportSkipped
 		return sURLs.WithError(nil)
 	}
 
@@ -274,6 +324,13 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		sURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = mj.storageClass
 	}
 
+	if mj.tags != "" {
+		if sURLs.TargetContent.Metadata == nil {
+			sURLs.TargetContent.Metadata = make(map[string]string)
+		}
+		sURLs.TargetContent.Metadata[tagsMetadataKey] = mj.tags
+	}
+
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 	mj.status.PrintMsg(mirrorMessage{
@@ -283,7 +340,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		TotalCount: sURLs.TotalCount,
 		TotalSize:  sURLs.TotalSize,
 	})
-	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB)
+	return uploadSourceToTargetURLWithRetry(ctx, sURLs, mj.status, mj.maxRetries, mj.encKeyDB)
 }
 
 // Update progress status
@@ -292,6 +349,7 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 	mj.status.Start()
 	defer mj.status.Finish()
 
+	var failed []string
 	for sURLs := range mj.statusCh {
 		if sURLs.Error != nil {
 			switch {
@@ -299,6 +357,7 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 				if !isErrIgnored(sURLs.Error) {
 					errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
 						fmt.Sprintf("Failed to copy `%s`.", sURLs.SourceContent.URL.String()))
+					failed = append(failed, sURLs.SourceContent.URL.String())
 					errDuringMirror = true
 				}
 			case sURLs.TargetContent != nil:
@@ -312,6 +371,18 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 			}
 		}
 
+		if sURLs.Error == nil && !mj.isWatch {
+			// Record progress, objects notified by the watcher
+			// are not part of the session data.
+			if sURLs.SourceContent != nil {
+				mj.session.Header.LastCopied = sURLs.SourceContent.URL.String()
+				mj.session.Save()
+			} else if sURLs.TargetContent != nil {
+				mj.session.Header.LastRemoved = sURLs.TargetContent.URL.String()
+				mj.session.Save()
+			}
+		}
+
 		if sURLs.SourceContent != nil {
 		} else if sURLs.TargetContent != nil {
 			// Construct user facing message and path.
@@ -321,6 +392,10 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 		}
 	}
 
+	if len(failed) > 0 {
+		mj.status.PrintMsg(transferFailuresMessage{Failed: failed})
+	}
+
 	return
 }
 
@@ -361,8 +436,8 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 			// build target path, it is the relative of the eventPath with the sourceUrl
 			// joined to the targetURL.
 			sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
-			//Skip the object, if it matches the Exclude options provided
-			if matchExcludeOptions(mj.excludeOptions, sourceSuffix) {
+			//Skip the object, if its name is not selected by filters
+			if !mj.filter.matchName(filepath.ToSlash(sourceSuffix)) {
 				continue
 			}
 
@@ -486,7 +561,19 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 		mj.parallel.wait()
 	}
 
-	URLsCh := prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.isFake, mj.isOverwrite, mj.isRemove, mj.excludeOptions, mj.encKeyDB)
+	// Watch mode mirrors the source as it is listed, otherwise the
+	// URLs are read back from the session data file.
+	var URLsCh <-chan URLs
+	if mj.isWatch {
+		URLsCh = prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.isFake, mj.isOverwrite, mj.isRemove, mj.isChecksum, mj.filter, mj.encKeyDB)
+	} else {
+		URLsCh = mj.sessionURLs()
+	}
+
+	// isCopied and isRemoved return true if an object has been
+	// already copied or removed, when we resume from a session.
+	isCopied := isLastFactory(mj.session.Header.LastCopied)
+	isRemoved := isLastFactory(mj.session.Header.LastRemoved)
 
 	for {
 		select {
@@ -502,12 +589,6 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 			}
 
 			if sURLs.SourceContent != nil {
-				if mj.olderThan != "" && isOlder(sURLs.SourceContent.Time, mj.olderThan) {
-					continue
-				}
-				if mj.newerThan != "" && isNewer(sURLs.SourceContent.Time, mj.newerThan) {
-					continue
-				}
 				// copy
 				totalBytes += sURLs.SourceContent.Size
 			}
@@ -522,6 +603,20 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 			// Save totalSize.
 			sURLs.TotalSize = mj.TotalBytes
 
+			if !mj.isWatch {
+				// Skip objects handled before the session was interrupted.
+				if sURLs.SourceContent != nil && isCopied(sURLs.SourceContent.URL.String()) {
+					mj.status.Add(sURLs.SourceContent.Size)
+					globalTransferReport.addURLs(reportSkipped, sURLs, 0)
+					continue
+				}
+				if sURLs.SourceContent == nil && sURLs.TargetContent != nil && mj.isRemove &&
+					isRemoved(sURLs.TargetContent.URL.String()) {
+					globalTransferReport.addURLs(reportSkipped, sURLs, 0)
+					continue
+				}
+			}
+
 			if sURLs.SourceContent != nil {
 				mj.queueCh <- func() URLs {
 					return mj.doMirror(ctx, cancelMirror, sURLs)
@@ -534,11 +629,92 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 		case <-mj.trapCh:
 			stopParallel()
 			cancelMirror()
+			if !mj.isWatch {
+				// Session can be resumed later.
+				mj.session.CloseAndDie()
+			}
 			return
 		}
 	}
 }
 
+// doPrepareMirrorURLs scans source and target and records the URLs to
+// mirror in the session data file.
+func (mj *mirrorJob) doPrepareMirrorURLs() {
+	// Create a session data file to store the processed URLs.
+	dataFP := mj.session.NewDataWriter()
+
+	var scanBar scanBarFunc
+	if !globalQuiet && !globalJSON { // set up progress bar
+		scanBar = scanBarFactory()
+	}
+
+	URLsCh := prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.isFake, mj.isOverwrite, mj.isRemove, mj.isChecksum, mj.filter, mj.encKeyDB)
+	for {
+		select {
+		case sURLs, ok := <-URLsCh:
+			if !ok { // Done with URL preparation
+				if !globalQuiet && !globalJSON {
+					console.Eraseline()
+				}
+				mj.session.Save()
+				return
+			}
+			if sURLs.Error != nil {
+				mj.session.Delete()
+				fatalIf(sURLs.Error.Trace(), "Unable to prepare URLs for mirroring.")
+			}
+
+			jsonData, e := json.Marshal(sURLs)
+			if e != nil {
+				mj.session.Delete()
+				fatalIf(probe.NewError(e), "Unable to prepare URLs for mirroring. Error in JSON marshaling.")
+			}
+			fmt.Fprintln(dataFP, string(jsonData))
+
+			if scanBar != nil {
+				if sURLs.SourceContent != nil {
+					scanBar(sURLs.SourceContent.URL.String())
+				} else if sURLs.TargetContent != nil {
+					scanBar(sURLs.TargetContent.URL.String())
+				}
+			}
+		case <-mj.trapCh:
+			// Print in new line and adjust to top so that we don't print over the ongoing scan bar
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			mj.session.Delete() // If we are interrupted during the URL scanning, we drop the session.
+			os.Exit(0)
+		}
+	}
+}
+
+// sessionURLs reads the URLs to mirror from the session data file.
+func (mj *mirrorJob) sessionURLs() <-chan URLs {
+	URLsCh := make(chan URLs)
+	go func() {
+		defer close(URLsCh)
+
+		urlScanner := bufio.NewScanner(mj.session.NewDataReader())
+		for urlScanner.Scan() {
+			var sURLs URLs
+			// Unmarshal URLs from each line. This expects each line to be
+			// an entire JSON object.
+			if e := json.Unmarshal([]byte(urlScanner.Text()), &sURLs); e != nil {
+				URLsCh <- URLs{Error: probe.NewError(e).Trace(urlScanner.Text())}
+				return
+			}
+			sURLs.encKeyDB = mj.encKeyDB
+			URLsCh <- sURLs
+		}
+		if e := urlScanner.Err(); e != nil {
+			URLsCh <- URLs{Error: probe.NewError(e)}
+		}
+	}()
+	return URLsCh
+}
+
 // when using a struct for copying, we could save a lot of passing of variables
 func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc) bool {
 
@@ -569,7 +745,7 @@ func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc
 	return mj.monitorMirrorStatus()
 }
 
-func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch bool, excludeOptions []string, olderThan, newerThan string, storageClass string, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
+func newMirrorJob(session *sessionV8, srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch, isChecksum bool, filter *objectFilter, storageClass, tags string, minWorkers, maxWorkers, maxRetries int, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
 	mj := mirrorJob{
 		trapCh: signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL),
 		m:      new(sync.Mutex),
@@ -577,20 +753,22 @@ func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch
 		sourceURL: srcURL,
 		targetURL: dstURL,
 
-		isFake:         isFake,
-		isRemove:       isRemove,
-		isOverwrite:    isOverwrite,
-		isWatch:        isWatch,
-		excludeOptions: excludeOptions,
-		olderThan:      olderThan,
-		newerThan:      newerThan,
-		storageClass:   storageClass,
-		encKeyDB:       encKeyDB,
-		statusCh:       make(chan URLs),
-		watcher:        NewWatcher(UTCNow()),
+		isFake:       isFake,
+		isRemove:     isRemove,
+		isOverwrite:  isOverwrite,
+		isWatch:      isWatch,
+		isChecksum:   isChecksum,
+		filter:       filter,
+		storageClass: storageClass,
+		tags:         tags,
+		maxRetries:   maxRetries,
+		encKeyDB:     encKeyDB,
+		session:      session,
+		statusCh:     make(chan URLs),
+		watcher:      NewWatcher(UTCNow()),
 	}
 
-	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh)
+	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh, minWorkers, maxWorkers)
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
@@ -629,25 +807,34 @@ func copyBucketPolicies(srcClt, dstClt Client, isOverwrite bool) *probe.Error {
 	return nil
 }
 
-// runMirror - mirrors all buckets to another S3 server
-func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
-	// This is kept for backward compatibility, `--force` means
-	// --overwrite.
-	isOverwrite := ctx.Bool("force")
-	if !isOverwrite {
-		isOverwrite = ctx.Bool("overwrite")
-	}
+// runMirror - mirrors all buckets to another S3 server, as recorded
+// in the session.
+func runMirror(session *sessionV8, encKeyDB map[string][]prefixSSEPair) bool {
+	srcURL := session.Header.CommandArgs[0]
+	dstURL := session.Header.CommandArgs[1]
+
+	isOverwrite := session.Header.CommandBoolFlags["overwrite"]
+	isPreservePolicy := session.Header.CommandBoolFlags["a"]
+
+	minWorkers, maxWorkers, err := parallelLimits(session.Header.CommandIntFlags["parallel"],
+		session.Header.CommandIntFlags["min-parallel"], session.Header.CommandIntFlags["max-parallel"])
+	fatalIf(err, "Unable to parse number of parallel transfers.")
+
+	filter, err := newObjectFilter(session.Header.CommandStringFlags)
+	fatalIf(err, "Unable to parse filters.")
 
 	// Create a new mirror job and execute it
-	mj := newMirrorJob(srcURL, dstURL,
-		ctx.Bool("fake"),
-		ctx.Bool("remove"),
+	mj := newMirrorJob(session, srcURL, dstURL,
+		session.Header.CommandBoolFlags["fake"],
+		session.Header.CommandBoolFlags["remove"],
 		isOverwrite,
-		ctx.Bool("watch"),
-		ctx.StringSlice("exclude"),
-		ctx.String("older-than"),
-		ctx.String("newer-than"),
-		ctx.String("storage-class"),
+		session.Header.CommandBoolFlags["watch"],
+		session.Header.CommandBoolFlags["checksum"],
+		filter,
+		session.Header.CommandStringFlags["storage-class"],
+		session.Header.CommandStringFlags["tags"],
+		minWorkers, maxWorkers,
+		session.Header.CommandIntFlags["retry"],
 		encKeyDB)
 
 	srcClt, err := newClient(srcURL)
@@ -656,7 +843,7 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	dstClt, err := newClient(dstURL)
 	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
 
-	if ctx.Bool("a") && (srcClt.GetURL().Type != objectStorage || dstClt.GetURL().Type != objectStorage) {
+	if isPreservePolicy && (srcClt.GetURL().Type != objectStorage || dstClt.GetURL().Type != objectStorage) {
 		fatalIf(errDummy(), "Synchronizing bucket policies is only possible when both source & target point to S3 servers.")
 	}
 
@@ -683,12 +870,12 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 
 			if d.Diff == differInFirst {
 				// Bucket only exists in the source, create the same bucket in the destination
-				if err := newDstClt.MakeBucket(ctx.String("region"), false); err != nil {
+				if err := newDstClt.MakeBucket(session.Header.CommandStringFlags["region"], false, false); err != nil {
 					errorIf(err, "Cannot created bucket in `"+newTgtURL+"`.")
 					continue
 				}
 				// Copy policy rules from source to dest if flag is activated
-				if ctx.Bool("a") {
+				if isPreservePolicy {
 					if err := copyBucketPolicies(srcClt, dstClt, isOverwrite); err != nil {
 						errorIf(err, "Cannot copy bucket policies to `"+newDstClt.GetURL().String()+"`.")
 					}
@@ -713,6 +900,10 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 		}
 	}
 
+	if !mj.isWatch && !session.HasData() {
+		mj.doPrepareMirrorURLs()
+	}
+
 	ctxt, cancelMirror := context.WithCancel(context.Background())
 	defer cancelMirror()
 
@@ -720,6 +911,19 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	return mj.mirror(ctxt, cancelMirror)
 }
 
+// doMirrorSession - mirrors as recorded in the session, it is used by
+// both new and resumed sessions.
+func doMirrorSession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) error {
+	// Additional command specific theme customization.
+	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TransferFailed", color.New(color.FgRed, color.Bold))
+
+	if errorDetected := runMirror(session, encKeyDB); errorDetected {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
 // Main entry point for mirror command.
 func mainMirror(ctx *cli.Context) error {
 	// Parse encryption keys per command.
@@ -729,17 +933,76 @@ func mainMirror(ctx *cli.Context) error {
 	// check 'mirror' cli arguments.
 	checkMirrorSyntax(ctx, encKeyDB)
 
-	// Additional command specific theme customization.
-	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	// Set bandwidth limits shared by all transfers.
+	err = setBandwidthLimits(ctx.String("limit-upload"), ctx.String("limit-download"))
+	fatalIf(err, "Unable to parse bandwidth limits.")
 
-	args := ctx.Args()
+	// Validate number of parallel transfers.
+	_, _, err = parallelLimits(ctx.Int("parallel"), ctx.Int("min-parallel"), ctx.Int("max-parallel"))
+	fatalIf(err, "Unable to parse number of parallel transfers.")
 
-	srcURL := args[0]
-	tgtURL := args[1]
+	if ctx.Int("retry") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("retry")), "Number of retries cannot be negative.")
+	}
 
-	if errorDetected := runMirror(srcURL, tgtURL, ctx, encKeyDB); errorDetected {
-		return exitStatus(globalErrorExitStatus)
+	// Absolute path of the report, sessions are resumed from their root path.
+	var reportPath string
+	if ctx.String("report") != "" {
+		var e error
+		reportPath, e = filepath.Abs(ctx.String("report"))
+		fatalIf(probe.NewError(e), "Unable to determine report path.")
 	}
+	err = openTransferReport(reportPath, false)
+	fatalIf(err, "Unable to create report.")
+	defer globalTransferReport.Close()
 
-	return nil
+	// This is kept for backward compatibility, `--force` means
+	// --overwrite.
+	isOverwrite := ctx.Bool("force")
+	if !isOverwrite {
+		isOverwrite = ctx.Bool("overwrite")
+	}
+
+	sseKeys := os.Getenv("MC_ENCRYPT_KEY")
+	if key := ctx.String("encrypt-key"); key != "" {
+		sseKeys = key
+	}
+	if sseKeys != "" {
+		sseKeys, err = getDecodedKey(sseKeys)
+		fatalIf(err, "Unable to parse encryption keys.")
+	}
+
+	session := newSessionV8()
+	session.Header.CommandType = "mirror"
+	session.Header.CommandBoolFlags["fake"] = ctx.Bool("fake")
+	session.Header.CommandBoolFlags["remove"] = ctx.Bool("remove")
+	session.Header.CommandBoolFlags["overwrite"] = isOverwrite
+	session.Header.CommandBoolFlags["watch"] = ctx.Bool("watch")
+	session.Header.CommandBoolFlags["checksum"] = ctx.Bool("checksum")
+	session.Header.CommandBoolFlags["a"] = ctx.Bool("a")
+	setFilterFlags(session.Header.CommandStringFlags, getFilterFlags(ctx))
+	session.Header.CommandStringFlags["storage-class"] = ctx.String("storage-class")
+	session.Header.CommandStringFlags["tags"] = ctx.String("tags")
+	session.Header.CommandStringFlags["region"] = ctx.String("region")
+	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
+	session.Header.CommandStringFlags["encrypt"] = ctx.String("encrypt")
+	session.Header.CommandStringFlags["limit-upload"] = ctx.String("limit-upload")
+	session.Header.CommandStringFlags["limit-download"] = ctx.String("limit-download")
+	session.Header.CommandIntFlags["parallel"] = ctx.Int("parallel")
+	session.Header.CommandIntFlags["min-parallel"] = ctx.Int("min-parallel")
+	session.Header.CommandIntFlags["max-parallel"] = ctx.Int("max-parallel")
+	session.Header.CommandIntFlags["retry"] = ctx.Int("retry")
+	session.Header.CommandStringFlags["report"] = reportPath
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+	session.Header.CommandArgs = ctx.Args()
+
+	e = doMirrorSession(session, encKeyDB)
+	session.Delete()
+
+	return e
 }
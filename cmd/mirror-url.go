@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/minio/cli"
@@ -54,6 +55,11 @@ func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		}
 	}
 
+	fatalIf(checkTagsFlag(ctx.String("tags")), "Invalid tags.")
+	if _, _, hostCfg := mustExpandAlias(tgtURL); hostCfg == nil && ctx.String("tags") != "" {
+		fatalIf(errInvalidArgument().Trace(tgtURL), "Tags can only be set on objects, target `"+tgtURL+"` is on the local filesystem.")
+	}
+
 	/****** Generic rules *******/
 	if !ctx.Bool("watch") {
 		c, srcContent, err := url2Stat(srcURL, false, encKeyDB)
@@ -86,7 +92,7 @@ func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	return false
 }
 
-func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, URLsCh chan<- URLs, encKeyDB map[string][]prefixSSEPair) {
+func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemove, isChecksum bool, filter *objectFilter, URLsCh chan<- URLs, encKeyDB map[string][]prefixSSEPair) {
 	// source and targets are always directories
 	sourceSeparator := string(newClientURL(sourceURL).Separator)
 	if !strings.HasSuffix(sourceURL, sourceSeparator) {
@@ -116,7 +122,7 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 	}
 
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(sourceClnt, targetClnt, sourceURL, targetURL) {
+	for diffMsg := range objectDifference(sourceClnt, targetClnt, sourceURL, targetURL, isChecksum) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error}
@@ -124,14 +130,14 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 		}
 
 		srcSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-		//Skip the source object if it matches the Exclude options provided
-		if matchExcludeOptions(excludeOptions, srcSuffix) {
+		//Skip the source object if it is not selected by filters
+		if srcContent := diffMsg.firstContent; srcContent != nil && !filter.match(filepath.ToSlash(srcSuffix), srcContent.Size, srcContent.Time) {
 			continue
 		}
 
 		tgtSuffix := strings.TrimPrefix(diffMsg.SecondURL, targetURL)
-		//Skip the target object if it matches the Exclude options provided
-		if matchExcludeOptions(excludeOptions, tgtSuffix) {
+		//Skip the target object if its name is not selected by filters
+		if diffMsg.secondContent != nil && !filter.matchName(filepath.ToSlash(tgtSuffix)) {
 			continue
 		}
 
@@ -140,9 +146,9 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 			// No difference, continue.
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInTime:
+		case differInSize, differInTime, differInContent:
 			if !isOverwrite && !isFake {
-				// Size or time differs but --overwrite not set.
+				// Size, time or content differs but --overwrite not set.
 				URLsCh <- URLs{Error: errOverWriteNotAllowed(diffMsg.SecondURL)}
 				continue
 			}
@@ -187,8 +193,8 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
-func prepareMirrorURLs(sourceURL string, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareMirrorURLs(sourceURL string, targetURL string, isFake, isOverwrite, isRemove, isChecksum bool, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	URLsCh := make(chan URLs)
-	go deltaSourceTarget(sourceURL, targetURL, isFake, isOverwrite, isRemove, excludeOptions, URLsCh, encKeyDB)
+	go deltaSourceTarget(sourceURL, targetURL, isFake, isOverwrite, isRemove, isChecksum, filter, URLsCh, encKeyDB)
 	return URLsCh
 }
@@ -0,0 +1,298 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// mv command flags.
+var (
+	mvFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "move recursively",
+		},
+		cli.StringFlag{
+			Name:  "storage-class, sc",
+			Usage: "set storage class for new object(s) on target",
+		},
+		cli.StringFlag{
+			Name:  "encrypt",
+			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys, 'prefix=kms:key-id' for SSE-KMS)",
+		},
+		cli.StringFlag{
+			Name:  "attr",
+			Usage: "add custom metadata for the object",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on the new object(s) on target, e.g. 'key1=value1&key2=value2'",
+		},
+	}
+)
+
+// Move command.
+var mvCmd = cli.Command{
+	Name:   "mv",
+	Usage:  "move objects",
+	Action: mainMove,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(append(append(append(append(append(append(append(mvFlags, ioFlags...), filterFlags...), sizeFilterFlags...), ageFilterFlags...), limitFlags...), parallelFlags...), retryFlags...), reportFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Objects are copied to the target like 'mc cp', server-side when source and target
+  are on the same server. A source is removed only after its copy is verified to
+  have the same size, and the same ETag when both ETags are MD5 sums of the data.
+  Interrupted moves can be resumed with 'mc session resume'.
+
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT:      list of comma delimited prefixes or prefix=kms:key-id values
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  01. Move a list of objects from local file system to Amazon S3 cloud storage.
+      $ {{.HelpName}} Music/*.ogg s3/jukebox/
+
+  02. Rename an object on MinIO cloud storage.
+      $ {{.HelpName}} play/mybucket/report.csv play/mybucket/report-2019.csv
+
+  03. Move a folder recursively from MinIO cloud storage to Amazon S3 cloud storage.
+      $ {{.HelpName}} --recursive play/mybucket/burningman2011/ s3/mybucket/
+
+  04. Move multiple local folders recursively to MinIO cloud storage.
+      $ {{.HelpName}} --recursive backup/2014/ backup/2015/ play/archive/
+
+  05. Move files older than 30 days from MinIO cloud storage to an archive bucket.
+      $ {{.HelpName}} --recursive --older-than 30d play/mybucket/logs/ play/archive/logs/
+
+  06. Move a local folder recursively to MinIO cloud storage and write a JSON report of every moved or failed object.
+      $ {{.HelpName}} --recursive --report move-report.json backup/2014/ play/archive/
+`,
+}
+
+// mainMove is the entry point for mv command.
+func mainMove(ctx *cli.Context) error {
+	return doCopyCommand(ctx, "mv")
+}
+
+// checkMoveSyntax verifies that no source is moved onto itself, it is
+// called once the arguments are validated by checkCopySyntax.
+func checkMoveSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	tgtURL := args[len(args)-1]
+	targetAlias, targetURL, _ := mustExpandAlias(tgtURL)
+	for _, srcURL := range args[:len(args)-1] {
+		sourceAlias, sourceURL, _ := mustExpandAlias(srcURL)
+		if isSameObject(sourceAlias, newClientURL(sourceURL), targetAlias, newClientURL(targetURL)) {
+			fatalIf(errInvalidArgument().Trace(srcURL, tgtURL), "Source `"+srcURL+"` and target `"+tgtURL+"` are the same.")
+		}
+	}
+}
+
+// isSameObject returns true if source and target are the same object
+// or file, possibly through different aliases of the same server.
+func isSameObject(sourceAlias string, sourceURL *clientURL, targetAlias string, targetURL *clientURL) bool {
+	if sourceAlias != targetAlias && !isSameEndpoint(sourceAlias, targetAlias) {
+		return false
+	}
+	sourcePath, targetPath := filepath.ToSlash(sourceURL.Path), filepath.ToSlash(targetURL.Path)
+	if sourceURL.Type == fileSystem {
+		sourcePath, _ = filepath.Abs(sourceURL.Path)
+		targetPath, _ = filepath.Abs(targetURL.Path)
+	}
+	return path.Clean(sourcePath) == path.Clean(targetPath)
+}
+
+// md5ETag returns the ETag of content if it is the MD5 sum of its data,
+// which is not the case of multipart uploads and encrypted objects.
+func md5ETag(content *clientContent) string {
+	etag := strings.Trim(content.ETag, "\"")
+	if len(etag) != 32 || len(content.EncryptionHeaders) > 0 {
+		return ""
+	}
+	return etag
+}
+
+// verifyMove verifies that target is a complete copy of source before
+// the source is removed.
+func verifyMove(source, target *clientContent) *probe.Error {
+	if source.Size != target.Size {
+		return probe.NewError(fmt.Errorf("target size %d does not match source size %d", target.Size, source.Size))
+	}
+	sourceETag, targetETag := md5ETag(source), md5ETag(target)
+	if sourceETag != "" && targetETag != "" && sourceETag != targetETag {
+		return probe.NewError(fmt.Errorf("target ETag `%s` does not match source ETag `%s`", targetETag, sourceETag))
+	}
+	return nil
+}
+
+// statContent returns the metadata of the object or file at urlStr.
+func statContent(alias, urlStr string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	content, err := clnt.Stat(false, true, sse)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	return content, nil
+}
+
+// removeContent removes the object or file at urlStr.
+func removeContent(alias, urlStr string) *probe.Error {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: *newClientURL(urlStr)}
+	close(contentCh)
+	var removeErr *probe.Error
+	for err := range clnt.Remove(false, false, contentCh) {
+		if err != nil && removeErr == nil {
+			removeErr = err.Trace(urlStr)
+		}
+	}
+	return removeErr
+}
+
+// isMoved returns true if the source of urls no longer exists and its
+// target has the size of the source, i.e. it was moved by a previous
+// run of a resumed session.
+func isMoved(urls URLs, srcSSE, tgtSSE encrypt.ServerSide) bool {
+	_, err := statContent(urls.SourceAlias, urls.SourceContent.URL.String(), srcSSE)
+	if err == nil {
+		return false
+	}
+	switch err.ToGoError().(type) {
+	case PathNotFound, ObjectMissing:
+	default:
+		return false
+	}
+	target, err := statContent(urls.TargetAlias, urls.TargetContent.URL.String(), tgtSSE)
+	return err == nil && target.Size == urls.SourceContent.Size
+}
+
+// moveSourceToTargetURL copies the source of urls to its target like
+// 'cp', the source is removed once the target is verified to match it.
+func moveSourceToTargetURL(ctx context.Context, urls URLs, pg ProgressReader, maxRetries int, encKeyDB map[string][]prefixSSEPair) URLs {
+	sourceAlias, sourceURL := urls.SourceAlias, urls.SourceContent.URL
+	targetAlias, targetURL := urls.TargetAlias, urls.TargetContent.URL
+	if isSameObject(sourceAlias, &sourceURL, targetAlias, &targetURL) {
+		return urls.WithError(probe.NewError(SameFile{Source: sourceURL.String(), Destination: targetURL.String()}))
+	}
+
+	srcSSE := getSSE(filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path)), encKeyDB[sourceAlias])
+	tgtSSE := getSSE(filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path)), encKeyDB[targetAlias])
+
+	status := uploadSourceToTargetURLWithRetry(ctx, urls, pg, maxRetries, encKeyDB)
+	if status.Error != nil {
+		// Objects after the last saved one of an interrupted session
+		// may have been moved already.
+		if isMoved(urls, srcSSE, tgtSSE) {
+			if progressReader, ok := pg.(*progressBar); ok {
+				progressReader.ProgressBar.Add64(urls.SourceContent.Size)
+			}
+			return urls.WithError(nil)
+		}
+		return status
+	}
+
+	// Stat the source again, it may have changed during the copy.
+	source, err := statContent(sourceAlias, sourceURL.String(), srcSSE)
+	if err != nil {
+		return urls.WithError(err.Trace(sourceURL.String()))
+	}
+	target, err := statContent(targetAlias, targetURL.String(), tgtSSE)
+	if err != nil {
+		return urls.WithError(err.Trace(targetURL.String()))
+	}
+	if err = verifyMove(source, target); err != nil {
+		return urls.WithError(err.Trace(sourceURL.String(), targetURL.String()))
+	}
+	if err = removeContent(sourceAlias, sourceURL.String()); err != nil {
+		return urls.WithError(err.Trace(sourceURL.String()))
+	}
+	return status
+}
+
+// removeMovedFolders removes the local folders of the moved files
+// left empty, deepest first. The source folders given to the command,
+// and folders which still have files, are kept.
+func removeMovedFolders(session *sessionV8, movedFolders map[string]bool) {
+	if !session.Header.CommandBoolFlags["recursive"] {
+		return
+	}
+	var roots []string
+	args := session.Header.CommandArgs
+	for _, sourceURL := range args[:len(args)-1] {
+		if _, _, hostCfg := mustExpandAlias(sourceURL); hostCfg != nil {
+			continue
+		}
+		if root, e := filepath.Abs(sourceURL); e == nil {
+			roots = append(roots, root)
+		}
+	}
+
+	folders := make(map[string]bool)
+	for folder := range movedFolders {
+		folder, e := filepath.Abs(folder)
+		if e != nil {
+			continue
+		}
+		for _, root := range roots {
+			for dir := folder; isSubFolder(dir, root); dir = filepath.Dir(dir) {
+				folders[dir] = true
+			}
+		}
+	}
+	var sorted []string
+	for folder := range folders {
+		sorted = append(sorted, folder)
+	}
+	// Sub folders have longer paths than their parents.
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, folder := range sorted {
+		os.Remove(folder)
+	}
+}
+
+// isSubFolder returns true if dir is inside root, and is not root.
+func isSubFolder(dir, root string) bool {
+	rel, e := filepath.Rel(root, dir)
+	return e == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
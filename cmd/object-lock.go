@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Retention modes of locked objects.
+const (
+	retentionGovernance = "GOVERNANCE"
+	retentionCompliance = "COMPLIANCE"
+)
+
+// Legal hold statuses of locked objects.
+const (
+	legalHoldOn  = "ON"
+	legalHoldOff = "OFF"
+)
+
+// Object lock headers of objects.
+const (
+	objectLockModeHeader            = "X-Amz-Object-Lock-Mode"
+	objectLockRetainUntilDateHeader = "X-Amz-Object-Lock-Retain-Until-Date"
+	objectLockLegalHoldHeader       = "X-Amz-Object-Lock-Legal-Hold"
+)
+
+// objectRetention is the retention of an object in the S3 API.
+type objectRetention struct {
+	XMLName         xml.Name   `xml:"Retention"`
+	Mode            string     `xml:"Mode,omitempty"`
+	RetainUntilDate *time.Time `xml:"RetainUntilDate,omitempty"`
+}
+
+// objectLegalHold is the legal hold of an object in the S3 API.
+type objectLegalHold struct {
+	XMLName xml.Name `xml:"LegalHold"`
+	Status  string   `xml:"Status"`
+}
+
+// parseRetentionMode parses a retention mode, case insensitively.
+func parseRetentionMode(modeStr string) (string, *probe.Error) {
+	mode := strings.ToUpper(modeStr)
+	if mode != retentionGovernance && mode != retentionCompliance {
+		return "", probe.NewError(fmt.Errorf("invalid retention mode `%s`, mode must be `governance` or `compliance`", modeStr))
+	}
+	return mode, nil
+}
+
+// parseRetentionValidity parses a retention validity given in days or
+// years, such as "30d" or "1y", and returns the date until which
+// objects are retained from now.
+func parseRetentionValidity(validityStr string, now time.Time) (time.Time, *probe.Error) {
+	invalidErr := probe.NewError(fmt.Errorf("invalid validity `%s`, validity must be a number of days or years, e.g. '30d' or '1y'", validityStr))
+	if len(validityStr) < 2 {
+		return time.Time{}, invalidErr
+	}
+	n, e := strconv.Atoi(validityStr[:len(validityStr)-1])
+	if e != nil || n <= 0 {
+		return time.Time{}, invalidErr
+	}
+	now = now.UTC().Truncate(time.Second)
+	switch strings.ToLower(validityStr[len(validityStr)-1:]) {
+	case "d":
+		return now.AddDate(0, 0, n), nil
+	case "y":
+		return now.AddDate(n, 0, 0), nil
+	}
+	return time.Time{}, invalidErr
+}
+
+// applyToObjects calls apply with the client of the object at urlStr,
+// or of each object under urlStr if isRecursive is set. Failures are
+// reported as "Unable to <action> `<url>`." and the remaining objects
+// are processed.
+func applyToObjects(urlStr string, isRecursive bool, action string, apply func(clnt Client, objectURL string) *probe.Error) error {
+	clnt, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	if !isRecursive {
+		if err = apply(clnt, urlStr); err != nil {
+			errorIf(err.Trace(urlStr), "Unable to "+action+" `"+urlStr+"`.")
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
+	targetAlias, _, _ := mustExpandAlias(urlStr)
+	var cErr error
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Unable to list `"+urlStr+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		objectURL := targetAlias + getKey(content)
+		objectClnt, err := newClient(objectURL)
+		if err == nil {
+			err = apply(objectClnt, objectURL)
+		}
+		if err != nil {
+			errorIf(err.Trace(objectURL), "Unable to "+action+" `"+objectURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+		}
+	}
+	return cErr
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetentionMode(t *testing.T) {
+	testCases := []struct {
+		modeStr string
+		mode    string
+		success bool
+	}{
+		{"governance", retentionGovernance, true},
+		{"COMPLIANCE", retentionCompliance, true},
+		{"Governance", retentionGovernance, true},
+		{"", "", false},
+		{"legal", "", false},
+	}
+	for i, testCase := range testCases {
+		mode, err := parseRetentionMode(testCase.modeStr)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if mode != testCase.mode {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.mode, mode)
+		}
+	}
+}
+
+func TestParseRetentionValidity(t *testing.T) {
+	now := time.Date(2019, 5, 20, 18, 24, 21, 500, time.UTC)
+	testCases := []struct {
+		validityStr string
+		retainUntil time.Time
+		success     bool
+	}{
+		{"30d", time.Date(2019, 6, 19, 18, 24, 21, 0, time.UTC), true},
+		{"1y", time.Date(2020, 5, 20, 18, 24, 21, 0, time.UTC), true},
+		{"7Y", time.Date(2026, 5, 20, 18, 24, 21, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"d", time.Time{}, false},
+		{"0d", time.Time{}, false},
+		{"-1d", time.Time{}, false},
+		{"30", time.Time{}, false},
+		{"1m", time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		retainUntil, err := parseRetentionValidity(testCase.validityStr, now)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if !retainUntil.Equal(testCase.retainUntil) {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.retainUntil, retainUntil)
+		}
+	}
+}
@@ -17,10 +17,13 @@
 package cmd
 
 import (
+	"errors"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/minio/mc/pkg/probe"
 )
 
 const (
@@ -42,6 +45,9 @@ type ParallelManager struct {
 	// Current threads number
 	workersNum uint32
 
+	// Maximum threads number
+	maxWorkers uint32
+
 	// Calculate sent bytes.
 	sentBytes int64
 
@@ -55,7 +61,7 @@ type ParallelManager struct {
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -137,23 +143,61 @@ func (p *ParallelManager) wait() {
 	close(p.stopMonitorCh)
 }
 
-// newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs) {
+// parallelLimits validates the requested number of parallel workers and
+// returns the number of workers to start with and the maximum number of
+// workers to scale up to. A fixed number of workers disables
+// auto-scaling and cannot be combined with a minimum or maximum.
+// Zero values mean defaults.
+func parallelLimits(parallel, minParallel, maxParallel int) (minWorkers, maxWorkers int, err *probe.Error) {
+	if parallel < 0 || minParallel < 0 || maxParallel < 0 {
+		return 0, 0, probe.NewError(errors.New("number of parallel workers cannot be negative"))
+	}
+	if parallel > 0 {
+		if minParallel > 0 || maxParallel > 0 {
+			return 0, 0, probe.NewError(errors.New("number of parallel workers cannot be combined with a minimum or maximum"))
+		}
+		return parallel, parallel, nil
+	}
+
+	maxWorkers = maxParallelWorkers
+	if maxParallel > 0 {
+		maxWorkers = maxParallel
+	}
+	minWorkers = runtime.NumCPU()
+	if minParallel > 0 {
+		minWorkers = minParallel
+	} else if minWorkers > maxWorkers {
+		minWorkers = maxWorkers
+	}
+	if minWorkers > maxWorkers {
+		return 0, 0, probe.NewError(errors.New("minimum number of parallel workers cannot be greater than the maximum"))
+	}
+	return minWorkers, maxWorkers, nil
+}
+
+// newParallelManager starts minWorkers workers waiting for executing
+// tasks, and adds more while the transfer speed increases up to
+// maxWorkers workers.
+func newParallelManager(resultCh chan URLs, minWorkers, maxWorkers int) (*ParallelManager, chan func() URLs) {
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    uint32(maxWorkers),
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan func() URLs),
 		resultCh:      resultCh,
 	}
 
-	// Start with runtime.NumCPU().
-	for i := 0; i < runtime.NumCPU(); i++ {
+	// Start with minWorkers.
+	for i := 0; i < minWorkers; i++ {
 		p.addWorker()
 	}
 
-	// Start monitoring tasks progress
-	p.monitorProgress()
+	// Start monitoring tasks progress, unless the number
+	// of workers is fixed.
+	if maxWorkers > minWorkers {
+		p.monitorProgress()
+	}
 
 	return p, p.queueCh
 }
@@ -0,0 +1,79 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestParallelLimits(t *testing.T) {
+	numCPU := runtime.NumCPU()
+	testCases := []struct {
+		parallel, minParallel, maxParallel int
+		expectedMin, expectedMax           int
+		shouldFail                         bool
+	}{
+		{0, 0, 0, numCPU, maxParallelWorkers, false},
+		{4, 0, 0, 4, 4, false},
+		{0, 2, 8, 2, 8, false},
+		{0, 1, 0, 1, maxParallelWorkers, false},
+		{0, 0, 1, 1, 1, false},
+		{4, 2, 0, 0, 0, true},
+		{0, 8, 2, 0, 0, true},
+		{-1, 0, 0, 0, 0, true},
+	}
+	for i, testCase := range testCases {
+		minWorkers, maxWorkers, err := parallelLimits(testCase.parallel, testCase.minParallel, testCase.maxParallel)
+		if testCase.shouldFail {
+			if err == nil {
+				t.Fatalf("Test %d: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if minWorkers != testCase.expectedMin || maxWorkers != testCase.expectedMax {
+			t.Fatalf("Test %d: expected (%d, %d), got (%d, %d)", i+1,
+				testCase.expectedMin, testCase.expectedMax, minWorkers, maxWorkers)
+		}
+	}
+}
+
+func TestParallelManagerFixedWorkers(t *testing.T) {
+	resultCh := make(chan URLs)
+	p, queueCh := newParallelManager(resultCh, 3, 3)
+	go func() {
+		for i := 0; i < 10; i++ {
+			queueCh <- func() URLs { return URLs{} }
+		}
+		close(queueCh)
+		p.wait()
+		close(resultCh)
+	}()
+	count := 0
+	for range resultCh {
+		count++
+	}
+	if count != 10 {
+		t.Fatalf("expected 10 results, got %d", count)
+	}
+	if p.workersNum != 3 {
+		t.Fatalf("expected 3 workers, got %d", p.workersNum)
+	}
+}
@@ -17,10 +17,12 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"syscall"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -28,7 +30,16 @@ var (
 	pipeFlags = []cli.Flag{
 		cli.StringFlag{
 			Name:  "encrypt",
-			Usage: "encrypt objects (using server-side encryption with server managed keys)",
+			Usage: "encrypt objects (using server-side encryption with server managed keys, 'prefix=kms:key-id' for SSE-KMS)",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on the object, e.g. 'key1=value1&key2=value2'",
+		},
+		// Only uploads are throttled, pipe never downloads.
+		cli.StringFlag{
+			Name:  "limit-upload",
+			Usage: "limit upload bandwidth, e.g. '10MiB/s'",
 		},
 	}
 )
@@ -50,7 +61,7 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
 ENVIRONMENT VARIABLES:
-  MC_ENCRYPT:      list of comma delimited prefix values
+  MC_ENCRYPT:      list of comma delimited prefixes or prefix=kms:key-id values
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
 EXAMPLES:
@@ -65,10 +76,19 @@ EXAMPLES:
 
   4. Stream MySQL database dump to Amazon S3 directly.
      $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+  5. Stream MySQL database dump to Amazon S3 directly, limiting the upload bandwidth to 5MiB/s.
+     $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --limit-upload 5MiB/s s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+  6. Stream MySQL database dump to Amazon S3 directly and tag the object.
+     $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --tags "type=backup&db=accounts" s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+  7. Stream MySQL database dump to Amazon S3 directly, encrypting the object with the KMS key 'my-key-id'.
+     $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --encrypt "s3/sql-backups=kms:my-key-id" s3/sql-backups/backups/accountsdb-oct-9-2015.sql
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func pipe(targetURL, tags string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	if targetURL == "" {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
@@ -76,10 +96,20 @@ func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	alias, _ := url2Alias(targetURL)
 	sseKey := getSSE(targetURL, encKeyDB[alias])
 
+	// Throttle stdin if uploading to an object storage.
+	var reader io.Reader = os.Stdin
+	if mustGetHostConfig(alias) != nil {
+		reader = hookreader.NewHook(os.Stdin, newLimitedProgress(nil, fileSystem, objectStorage))
+	}
+
 	// Stream from stdin to multiple objects until EOF.
 	// Ignore size, since os.Stat() would not return proper size all the time
 	// for local filesystem for example /proc files.
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, sseKey)
+	var metadata map[string]string
+	if tags != "" {
+		metadata = map[string]string{tagsMetadataKey: tags}
+	}
+	_, err := putTargetStreamWithURL(targetURL, reader, -1, metadata, sseKey)
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
@@ -96,6 +126,10 @@ func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
 	}
+	if ctx.String("tags") != "" && len(ctx.Args()) == 0 {
+		fatalIf(errInvalidArgument(), "Tags can only be set when a target is given.")
+	}
+	fatalIf(checkTagsFlag(ctx.String("tags")), "Invalid tags.")
 }
 
 // mainPipe is the main entry point for pipe command.
@@ -107,13 +141,17 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	// Set the upload bandwidth limit.
+	err = setBandwidthLimits(ctx.String("limit-upload"), "")
+	fatalIf(err, "Unable to parse bandwidth limits.")
+
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil)
+		err = pipe("", "", nil)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB)
+		err = pipe(URLs[0], ctx.String("tags"), encKeyDB)
 		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
 	}
 
@@ -0,0 +1,143 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	replicateAddFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "id of the rule, a random id is used if not set; an existing rule with the same id is replaced",
+		},
+		cli.StringFlag{
+			Name:  "arn",
+			Usage: "ARN of the destination bucket, e.g. 'arn:aws:s3:::mybucket'",
+		},
+		cli.StringFlag{
+			Name:  "prefix",
+			Usage: "replicate objects with this prefix",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "replicate objects with these tags, e.g. 'key1=value1&key2=value2'",
+		},
+		cli.IntFlag{
+			Name:  "priority",
+			Usage: "priority of the rule, the rule with the highest priority applies to objects selected by several rules",
+		},
+		cli.StringFlag{
+			Name:  "storage-class",
+			Usage: "storage class of the replicated objects",
+		},
+		cli.BoolFlag{
+			Name:  "replicate-delete-markers",
+			Usage: "replicate delete markers",
+		},
+		cli.StringFlag{
+			Name:  "role",
+			Usage: "ARN of the role assumed to replicate objects, it applies to all rules of the bucket and is required if the bucket has none",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "add the rule disabled",
+		},
+	}
+)
+
+var replicateAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "add or replace a bucket replication rule",
+	Action: mainReplicateAdd,
+	Before: setGlobalsFromContext,
+	Flags:  append(replicateAddFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Replicate all objects of a bucket with no replication rules to another bucket.
+     $ {{.HelpName}} --id backup --role "arn:aws:iam::123456789012:role/replication" \
+       --arn "arn:aws:s3:::backup" s3/mybucket
+
+  2. Replicate objects under 'docs/' tagged 'project=mc', including delete markers.
+     $ {{.HelpName}} --prefix "docs/" --tags "project=mc" --priority 2 --replicate-delete-markers \
+       --arn "arn:aws:s3:::backup" s3/mybucket
+
+  3. Replicate objects under 'logs/' to the STANDARD_IA storage class.
+     $ {{.HelpName}} --prefix "logs/" --priority 3 --storage-class STANDARD_IA \
+       --arn "arn:aws:s3:::backup" s3/mybucket
+`,
+}
+
+// checkReplicateAddSyntax - validate all the passed arguments
+func checkReplicateAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+	}
+	if ctx.String("arn") == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--arn flag needs to be passed.")
+	}
+}
+
+func mainReplicateAdd(ctx *cli.Context) error {
+	console.SetColor("Replicate", color.New(color.FgGreen, color.Bold))
+
+	checkReplicateAddSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	rule, err := replicateOptions{
+		ID:                     ctx.String("id"),
+		Prefix:                 ctx.String("prefix"),
+		Tags:                   ctx.String("tags"),
+		ARN:                    ctx.String("arn"),
+		Priority:               ctx.Int("priority"),
+		StorageClass:           ctx.String("storage-class"),
+		ReplicateDeleteMarkers: ctx.Bool("replicate-delete-markers"),
+		Disable:                ctx.Bool("disable"),
+	}.toRule()
+	fatalIf(err, "Invalid replication rule.")
+
+	op := "add"
+	role := ctx.String("role")
+	replicationRules.update(urlStr, func(rules bucketRules) *probe.Error {
+		replication := rules.(*replicationConfiguration)
+		if replication.addRule(rule) {
+			op = "update"
+		}
+		if role != "" {
+			replication.Role = role
+		}
+		if replication.Role == "" {
+			fatalIf(errInvalidArgument().Trace(urlStr), "--role flag needs to be passed, `"+urlStr+"` has no replication role.")
+		}
+		return replication.validate()
+	})
+
+	printMsg(replicateMessage{op: op, Target: urlStr, ID: rule.ID, Rule: &rule})
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var replicateListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list bucket replication rules",
+	Action: mainReplicateList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the replication rules of a bucket.
+     $ {{.HelpName}} s3/mybucket
+
+  2. List the replication rules of a bucket in JSON.
+     $ {{.HelpName}} --json s3/mybucket
+`,
+}
+
+// checkReplicateListSyntax - validate all the passed arguments
+func checkReplicateListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+func mainReplicateList(ctx *cli.Context) error {
+	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Replicate", color.New(color.FgGreen, color.Bold))
+
+	checkReplicateListSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	replication := replicationRules.fetch(urlStr).(*replicationConfiguration)
+	if len(replication.Rules) == 0 {
+		if !globalJSON {
+			console.Println(replicationRules.opString("empty", urlStr, ""))
+		}
+		return nil
+	}
+
+	if !globalJSON {
+		replicateTable.printHeaders()
+	}
+	for i := range replication.Rules {
+		rule := replication.Rules[i]
+		printMsg(replicateMessage{op: "list", Target: urlStr, ID: rule.ID, Rule: &rule})
+	}
+	return nil
+}
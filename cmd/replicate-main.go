@@ -0,0 +1,114 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	replicateFlags = []cli.Flag{}
+)
+
+var replicateCmd = cli.Command{
+	Name:            "replicate",
+	Usage:           "manage bucket replication rules",
+	HideHelpCommand: true,
+	Action:          mainReplicate,
+	Before:          setGlobalsFromContext,
+	Flags:           append(replicateFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		replicateListCmd,
+		replicateAddCmd,
+		replicateRemoveCmd,
+		replicateExportCmd,
+		replicateImportCmd,
+	},
+}
+
+// mainReplicate is the handle for "mc replicate" command.
+func mainReplicate(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "list", "add", "remove" have their own main.
+}
+
+// replicationRules gets and sets the replication rules of buckets.
+var replicationRules = bucketRulesKind{
+	name:  "replication",
+	theme: "Replicate",
+	get: func(c *s3Client) (bucketRules, *probe.Error) {
+		return c.GetReplication()
+	},
+	set: func(c *s3Client, rules bucketRules) *probe.Error {
+		replication, _ := rules.(*replicationConfiguration)
+		return c.SetReplication(replication)
+	},
+	parse: func(data []byte) (bucketRules, *probe.Error) {
+		return parseReplicationConfiguration(data)
+	},
+	message: func(op, target, id string) message {
+		return replicateMessage{op: op, Target: target, ID: id}
+	},
+}
+
+// replicateMessage container for replication rule messages.
+type replicateMessage struct {
+	op     string
+	Status string           `json:"status"`
+	Target string           `json:"target"`
+	ID     string           `json:"id,omitempty"`
+	Rule   *replicationRule `json:"rule,omitempty"`
+}
+
+// replicateTable lists replication rules.
+var replicateTable = rulesTable{
+	{"ID", 20},
+	{"Priority", 8},
+	{"Prefix", 16},
+	{"Tags", 20},
+	{"Status", 8},
+	{"DeleteMarker", 12},
+	{"Destination", -1},
+}
+
+func (r replicateMessage) String() string {
+	if r.op != "list" {
+		return replicationRules.opString(r.op, r.Target, r.ID)
+	}
+	destination := r.Rule.Destination.Bucket
+	if r.Rule.Destination.StorageClass != "" {
+		destination += " (" + r.Rule.Destination.StorageClass + ")"
+	}
+	var priority string
+	if !r.Rule.isV1() {
+		priority = strconv.Itoa(r.Rule.Priority)
+	}
+	return replicateTable.buildRow(r.Rule.ID, priority, r.Rule.prefix(), tagsString(r.Rule.tags()),
+		r.Rule.Status, r.Rule.DeleteMarkerReplication.Status, destination)
+}
+
+func (r replicateMessage) JSON() string {
+	r.Status = "success"
+	replicateMessageJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(replicateMessageJSONBytes)
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	replicateRemoveFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "id of the rule to remove",
+		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "remove all replication rules of the bucket",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "allow removing all replication rules",
+		},
+	}
+)
+
+var replicateRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove a bucket replication rule; '--all --force' removes all rules",
+	Action: mainReplicateRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(replicateRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the replication rule 'backup' of a bucket.
+     $ {{.HelpName}} --id backup s3/mybucket
+
+  2. Remove all replication rules of a bucket. --force flag is mandatory here
+     $ {{.HelpName}} --all --force s3/mybucket
+`,
+}
+
+func mainReplicateRemove(ctx *cli.Context) error {
+	console.SetColor("Replicate", color.New(color.FgGreen, color.Bold))
+
+	replicationRules.remove(ctx)
+	return nil
+}
@@ -0,0 +1,309 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Replication rule status values, they are also used for the
+// replication of delete markers.
+const (
+	replicationStatusEnabled  = "Enabled"
+	replicationStatusDisabled = "Disabled"
+)
+
+// replicationConfiguration is the replication configuration of a
+// bucket, Role is the ARN of the IAM role assumed to replicate objects.
+type replicationConfiguration struct {
+	XMLName xml.Name          `xml:"ReplicationConfiguration" json:"-"`
+	Role    string            `xml:"Role" json:"role"`
+	Rules   []replicationRule `xml:"Rule" json:"rules"`
+}
+
+// replicationRule replicates the objects selected by its filter to a
+// destination bucket, the rule with the highest priority applies when
+// several rules select an object.
+type replicationRule struct {
+	ID       string `xml:"ID" json:"id"`
+	Status   string `xml:"Status" json:"status"`
+	Priority int    `xml:"Priority" json:"priority"`
+	// Filter selects objects like the filter of lifecycle rules, it
+	// is nil for the legacy V1 rules which select objects by Prefix.
+	Filter                    *lifecycleFilter            `xml:"Filter,omitempty" json:"filter,omitempty"`
+	Prefix                    string                      `xml:"Prefix,omitempty" json:"prefix,omitempty"`
+	DeleteMarkerReplication   replicationStatusElement    `xml:"DeleteMarkerReplication" json:"deleteMarkerReplication"`
+	ExistingObjectReplication *replicationStatusElement   `xml:"ExistingObjectReplication,omitempty" json:"existingObjectReplication,omitempty"`
+	SourceSelectionCriteria   *replicationSourceSelection `xml:"SourceSelectionCriteria,omitempty" json:"sourceSelectionCriteria,omitempty"`
+	Destination               replicationDestination      `xml:"Destination" json:"destination"`
+	// Unknown holds the elements of the rule mc does not know about,
+	// they are sent back as is when the configuration is written.
+	Unknown []xmlElement `xml:",any" json:"-"`
+	// Raw is the rule as read, legacy V1 rules are written back as is.
+	Raw string `xml:",innerxml" json:"-"`
+}
+
+// isV1 returns true for the legacy rules without filters, they have
+// no priority and no delete marker replication.
+func (r replicationRule) isV1() bool {
+	return r.Filter == nil
+}
+
+// MarshalXML writes the legacy V1 rules as they were read, and the
+// other rules from their fields.
+func (r replicationRule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.isV1() && r.Raw != "" {
+		return e.EncodeElement(struct {
+			Inner string `xml:",innerxml"`
+		}{r.Raw}, start)
+	}
+	// rule has the fields of replicationRule without its methods.
+	type rule replicationRule
+	r.Raw = ""
+	return e.EncodeElement(rule(r), start)
+}
+
+// replicationStatusElement enables or disables a replication feature,
+// e.g. the replication of delete markers.
+type replicationStatusElement struct {
+	Status string `xml:"Status" json:"status"`
+}
+
+// replicationSourceSelection selects objects by how they are encrypted
+// or modified, in addition to the filter.
+type replicationSourceSelection struct {
+	SseKmsEncryptedObjects *replicationStatusElement `xml:"SseKmsEncryptedObjects,omitempty" json:"sseKmsEncryptedObjects,omitempty"`
+	ReplicaModifications   *replicationStatusElement `xml:"ReplicaModifications,omitempty" json:"replicaModifications,omitempty"`
+	Unknown                []xmlElement              `xml:",any" json:"-"`
+}
+
+// replicationDestination is the bucket objects are replicated to.
+type replicationDestination struct {
+	Bucket                   string                    `xml:"Bucket" json:"bucket"`
+	StorageClass             string                    `xml:"StorageClass,omitempty" json:"storageClass,omitempty"`
+	Account                  string                    `xml:"Account,omitempty" json:"account,omitempty"`
+	AccessControlTranslation *replicationAccessControl `xml:"AccessControlTranslation,omitempty" json:"accessControlTranslation,omitempty"`
+	EncryptionConfiguration  *replicationEncryption    `xml:"EncryptionConfiguration,omitempty" json:"encryptionConfiguration,omitempty"`
+	ReplicationTime          *replicationTime          `xml:"ReplicationTime,omitempty" json:"replicationTime,omitempty"`
+	Metrics                  *replicationMetrics       `xml:"Metrics,omitempty" json:"metrics,omitempty"`
+	Unknown                  []xmlElement              `xml:",any" json:"-"`
+}
+
+// replicationAccessControl changes the owner of the replicas to the
+// account of the destination bucket.
+type replicationAccessControl struct {
+	Owner string `xml:"Owner" json:"owner"`
+}
+
+// replicationEncryption is the KMS key the replicas are encrypted with.
+type replicationEncryption struct {
+	ReplicaKmsKeyID string `xml:"ReplicaKmsKeyID" json:"replicaKmsKeyID"`
+}
+
+// replicationTime is the time objects must be replicated within.
+type replicationTime struct {
+	Status string             `xml:"Status" json:"status"`
+	Time   replicationMinutes `xml:"Time" json:"time"`
+}
+
+// replicationMetrics enables the replication metrics and events.
+type replicationMetrics struct {
+	Status         string              `xml:"Status" json:"status"`
+	EventThreshold *replicationMinutes `xml:"EventThreshold,omitempty" json:"eventThreshold,omitempty"`
+}
+
+// replicationMinutes is a duration of replication settings.
+type replicationMinutes struct {
+	Minutes int `xml:"Minutes" json:"minutes"`
+}
+
+// prefix returns the prefix of the objects selected by the rule.
+func (r replicationRule) prefix() string {
+	if r.isV1() {
+		return r.Prefix
+	}
+	return lifecycleRule{Filter: *r.Filter}.prefix()
+}
+
+// tags returns the tags of the objects selected by the rule.
+func (r replicationRule) tags() []objectTag {
+	if r.isV1() {
+		return nil
+	}
+	return lifecycleRule{Filter: *r.Filter}.tags()
+}
+
+// replicateOptions are the options given to create a replication rule.
+type replicateOptions struct {
+	ID                     string
+	Prefix                 string
+	Tags                   string
+	ARN                    string
+	Priority               int
+	StorageClass           string
+	ReplicateDeleteMarkers bool
+	Disable                bool
+}
+
+// toRule creates a validated replication rule, a random ID is used if
+// none is given.
+func (opts replicateOptions) toRule() (replicationRule, *probe.Error) {
+	rule := replicationRule{
+		ID:                      opts.ID,
+		Status:                  replicationStatusEnabled,
+		Priority:                opts.Priority,
+		DeleteMarkerReplication: replicationStatusElement{Status: replicationStatusDisabled},
+		Destination:             replicationDestination{Bucket: opts.ARN, StorageClass: opts.StorageClass},
+	}
+	if rule.ID == "" {
+		rule.ID = newRandomID(20)
+	}
+	if opts.Disable {
+		rule.Status = replicationStatusDisabled
+	}
+	if opts.ReplicateDeleteMarkers {
+		rule.DeleteMarkerReplication.Status = replicationStatusEnabled
+	}
+
+	tags, err := parseTags(opts.Tags)
+	if err != nil {
+		return rule, err.Trace(opts.Tags)
+	}
+	filter := newLifecycleFilter(opts.Prefix, tags)
+	rule.Filter = &filter
+	return rule, validateReplicationRule(rule)
+}
+
+// validateReplicationRule verifies that a rule can be applied.
+func validateReplicationRule(rule replicationRule) *probe.Error {
+	if rule.ID == "" || len(rule.ID) > 255 {
+		return probe.NewError(errors.New("rule ID must be between 1 and 255 characters long")).Trace(rule.ID)
+	}
+	if rule.Status != replicationStatusEnabled && rule.Status != replicationStatusDisabled {
+		return probe.NewError(fmt.Errorf("rule status must be `%s` or `%s`", replicationStatusEnabled, replicationStatusDisabled)).Trace(rule.ID, rule.Status)
+	}
+	if status := rule.DeleteMarkerReplication.Status; !rule.isV1() && status != replicationStatusEnabled && status != replicationStatusDisabled {
+		return probe.NewError(fmt.Errorf("delete marker replication must be `%s` or `%s`", replicationStatusEnabled, replicationStatusDisabled)).Trace(rule.ID, status)
+	}
+	if rule.Priority < 0 {
+		return probe.NewError(errors.New("rule priority must be positive")).Trace(rule.ID)
+	}
+	if !strings.HasPrefix(rule.Destination.Bucket, "arn:") {
+		return probe.NewError(errors.New("destination must be the ARN of a bucket, e.g. 'arn:aws:s3:::mybucket'")).Trace(rule.ID, rule.Destination.Bucket)
+	}
+	return nil
+}
+
+// validate verifies that the rules of the configuration have distinct
+// IDs and priorities, legacy V1 rules cannot be mixed with other rules.
+func (c *replicationConfiguration) validate() *probe.Error {
+	ids := make(map[string]bool)
+	priorities := make(map[int]string)
+	var v1ID, v2ID string
+	for _, rule := range c.Rules {
+		if ids[rule.ID] {
+			return probe.NewError(fmt.Errorf("duplicate rule ID `%s`", rule.ID))
+		}
+		ids[rule.ID] = true
+		if err := validateReplicationRule(rule); err != nil {
+			return err.Trace(rule.ID)
+		}
+		if rule.isV1() {
+			v1ID = rule.ID
+			continue
+		}
+		v2ID = rule.ID
+		if id, ok := priorities[rule.Priority]; ok {
+			return probe.NewError(fmt.Errorf("rules `%s` and `%s` have the same priority %d", id, rule.ID, rule.Priority))
+		}
+		priorities[rule.Priority] = rule.ID
+	}
+	if v1ID != "" && v2ID != "" {
+		return probe.NewError(fmt.Errorf("legacy rule `%s` without a filter cannot be mixed with rule `%s`, "+
+			"export the configuration and import it back to convert its rules", v1ID, v2ID))
+	}
+	return nil
+}
+
+// normalize converts the imported rules to rules with filters and
+// priorities, delete markers are not replicated unless stated.
+func (c *replicationConfiguration) normalize() {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.DeleteMarkerReplication.Status == "" {
+			rule.DeleteMarkerReplication.Status = replicationStatusDisabled
+		}
+		if rule.isV1() || rule.Prefix != "" {
+			filter := newLifecycleFilter(rule.prefix(), rule.tags())
+			rule.Filter = &filter
+			rule.Prefix = ""
+		}
+		rule.Raw = ""
+	}
+}
+
+// parseReplicationConfiguration parses and validates a replication
+// configuration in the JSON format of 'mc replicate export' or in the
+// XML format of the S3 API.
+func parseReplicationConfiguration(data []byte) (*replicationConfiguration, *probe.Error) {
+	replication := &replicationConfiguration{}
+	var e error
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		e = xml.Unmarshal(data, replication)
+	} else {
+		e = json.Unmarshal(data, replication)
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	replication.normalize()
+	if err := replication.validate(); err != nil {
+		return nil, err
+	}
+	return replication, nil
+}
+
+// addRule adds rule to the configuration, it replaces the rule with
+// the same ID if any.
+func (c *replicationConfiguration) addRule(rule replicationRule) (replaced bool) {
+	for i := range c.Rules {
+		if c.Rules[i].ID == rule.ID {
+			c.Rules[i] = rule
+			return true
+		}
+	}
+	c.Rules = append(c.Rules, rule)
+	return false
+}
+
+// removeRule removes the rule with ID from the configuration.
+func (c *replicationConfiguration) removeRule(id string) (found bool) {
+	for i := range c.Rules {
+		if c.Rules[i].ID == id {
+			c.Rules = append(c.Rules[:i], c.Rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
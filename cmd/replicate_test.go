@@ -0,0 +1,267 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplicateOptionsToRule(t *testing.T) {
+	arn := "arn:aws:s3:::backup"
+	testCases := []struct {
+		opts    replicateOptions
+		success bool
+	}{
+		{replicateOptions{ID: "a", ARN: arn}, true},
+		{replicateOptions{ID: "a", ARN: arn, Prefix: "docs/", Tags: "k1=v1&k2=v2", Priority: 3}, true},
+		{replicateOptions{ID: "a", ARN: arn, StorageClass: "STANDARD_IA", ReplicateDeleteMarkers: true}, true},
+		// Destination is not an ARN.
+		{replicateOptions{ID: "a"}, false},
+		{replicateOptions{ID: "a", ARN: "backup"}, false},
+		{replicateOptions{ID: "a", ARN: arn, Priority: -1}, false},
+		{replicateOptions{ID: "a", ARN: arn, Tags: "k1=v1&k1=v2"}, false},
+	}
+
+	for i, testCase := range testCases {
+		_, err := testCase.opts.toRule()
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	rule, err := replicateOptions{ARN: arn, Disable: true, ReplicateDeleteMarkers: true}.toRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" || rule.Status != replicationStatusDisabled {
+		t.Errorf("expected a disabled rule with a random ID, got %+v", rule)
+	}
+	if rule.DeleteMarkerReplication.Status != replicationStatusEnabled {
+		t.Errorf("expected delete markers to be replicated, got %+v", rule)
+	}
+}
+
+func TestReplicationValidate(t *testing.T) {
+	rule := func(id string, priority int) replicationRule {
+		r, err := replicateOptions{ID: id, ARN: "arn:aws:s3:::backup", Priority: priority}.toRule()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	testCases := []struct {
+		rules   []replicationRule
+		success bool
+	}{
+		{nil, true},
+		{[]replicationRule{rule("a", 1), rule("b", 2)}, true},
+		// Duplicate IDs or priorities.
+		{[]replicationRule{rule("a", 1), rule("a", 2)}, false},
+		{[]replicationRule{rule("a", 1), rule("b", 1)}, false},
+	}
+	for i, testCase := range testCases {
+		replication := replicationConfiguration{Rules: testCase.rules}
+		if err := replication.validate(); (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestParseReplicationConfiguration(t *testing.T) {
+	// Rules with the deprecated prefix element get a filter.
+	data := []byte(`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Role>arn:aws:iam::123456789012:role/replication</Role>
+  <Rule>
+    <ID>backup</ID>
+    <Status>Enabled</Status>
+    <Priority>1</Priority>
+    <Prefix>docs/</Prefix>
+    <DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>
+    <Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination>
+  </Rule>
+</ReplicationConfiguration>`)
+	replication, err := parseReplicationConfiguration(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replication.Role != "arn:aws:iam::123456789012:role/replication" {
+		t.Errorf("unexpected role %s", replication.Role)
+	}
+	if len(replication.Rules) != 1 || replication.Rules[0].prefix() != "docs/" || replication.Rules[0].Prefix != "" {
+		t.Fatalf("unexpected rules %+v", replication.Rules)
+	}
+
+	// The JSON export can be imported back.
+	exported, e := json.Marshal(replication)
+	if e != nil {
+		t.Fatal(e)
+	}
+	imported, err := parseReplicationConfiguration(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported.Rules, replication.Rules) || imported.Role != replication.Role {
+		t.Errorf("expected %+v, got %+v", replication, imported)
+	}
+
+	for i, data := range []string{
+		`{"rules": [{"id": "a", "status": "On", "destination": {"bucket": "arn:aws:s3:::backup"}}]}`,
+		`{"rules": [{"id": "a", "status": "Enabled", "deleteMarkerReplication": {"status": "Disabled"}, "destination": {"bucket": "backup"}}]}`,
+		`<ReplicationConfiguration><Rule>`,
+	} {
+		if _, err := parseReplicationConfiguration([]byte(data)); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestReplicationAddRemoveRule(t *testing.T) {
+	replication := &replicationConfiguration{}
+	if replication.addRule(replicationRule{ID: "a", Status: replicationStatusEnabled}) {
+		t.Errorf("expected rule a to be added")
+	}
+	replication.addRule(replicationRule{ID: "b", Status: replicationStatusEnabled})
+	if !replication.addRule(replicationRule{ID: "a", Status: replicationStatusDisabled}) {
+		t.Errorf("expected rule a to be replaced")
+	}
+	if len(replication.Rules) != 2 || replication.Rules[0].Status != replicationStatusDisabled {
+		t.Errorf("unexpected rules %+v", replication.Rules)
+	}
+	if !replication.removeRule("a") || replication.removeRule("a") {
+		t.Errorf("expected rule a to be removed once")
+	}
+	if len(replication.Rules) != 1 || replication.Rules[0].ID != "b" {
+		t.Errorf("unexpected rules %+v", replication.Rules)
+	}
+}
+
+func TestReplicationRoundTrip(t *testing.T) {
+	replicationXML := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Role>arn:aws:iam::123456789012:role/replication</Role>
+  <Rule><ID>backup</ID><Status>Enabled</Status><Priority>1</Priority><Filter><Prefix>docs/</Prefix></Filter>
+    <DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>
+    <ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication>
+    <SourceSelectionCriteria><SseKmsEncryptedObjects><Status>Enabled</Status></SseKmsEncryptedObjects></SourceSelectionCriteria>
+    <Destination><Bucket>arn:aws:s3:::backup</Bucket><Account>210987654321</Account>
+      <AccessControlTranslation><Owner>Destination</Owner></AccessControlTranslation>
+      <EncryptionConfiguration><ReplicaKmsKeyID>arn:aws:kms:us-east-1:210987654321:key/replica</ReplicaKmsKeyID></EncryptionConfiguration>
+      <ReplicationTime><Status>Enabled</Status><Time><Minutes>15</Minutes></Time></ReplicationTime>
+      <Metrics><Status>Enabled</Status><EventThreshold><Minutes>15</Minutes></EventThreshold></Metrics>
+      <FutureSetting>on</FutureSetting></Destination>
+    <FutureAction><Days>3</Days></FutureAction></Rule>
+  <Rule><ID>old</ID><Status>Enabled</Status><Priority>2</Priority><Filter></Filter>
+    <Destination><Bucket>arn:aws:s3:::old</Bucket></Destination></Rule>
+</ReplicationConfiguration>`
+
+	replication, err := parseReplicationConfiguration([]byte(replicationXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := replication.Rules[0]
+	if rule.ExistingObjectReplication.Status != replicationStatusEnabled || rule.Destination.Account != "210987654321" ||
+		rule.Destination.ReplicationTime.Time.Minutes != 15 || rule.Destination.EncryptionConfiguration == nil {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+
+	// Removing a rule keeps all the elements of the other ones.
+	replication.removeRule("old")
+	replicationBytes, e := xml.Marshal(replication)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, element := range []string{
+		`<ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication>`,
+		`<SourceSelectionCriteria><SseKmsEncryptedObjects><Status>Enabled</Status></SseKmsEncryptedObjects></SourceSelectionCriteria>`,
+		`<Account>210987654321</Account>`,
+		`<AccessControlTranslation><Owner>Destination</Owner></AccessControlTranslation>`,
+		`<EncryptionConfiguration><ReplicaKmsKeyID>arn:aws:kms:us-east-1:210987654321:key/replica</ReplicaKmsKeyID></EncryptionConfiguration>`,
+		`<ReplicationTime><Status>Enabled</Status><Time><Minutes>15</Minutes></Time></ReplicationTime>`,
+		`<Metrics><Status>Enabled</Status><EventThreshold><Minutes>15</Minutes></EventThreshold></Metrics>`,
+		`<FutureSetting xmlns="http://s3.amazonaws.com/doc/2006-03-01/">on</FutureSetting></Destination>`,
+		`<FutureAction xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Days>3</Days></FutureAction></Rule>`,
+	} {
+		if !strings.Contains(string(replicationBytes), element) {
+			t.Errorf("expected %s in %s", element, replicationBytes)
+		}
+	}
+	if strings.Contains(string(replicationBytes), "arn:aws:s3:::old") {
+		t.Errorf("expected rule old to be removed, got %s", replicationBytes)
+	}
+
+	// Known elements are exported to JSON and imported back.
+	replicationJSON, e := json.Marshal(replication)
+	if e != nil {
+		t.Fatal(e)
+	}
+	imported, err := parseReplicationConfiguration(replicationJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule.Unknown = nil
+	rule.Destination.Unknown = nil
+	if !reflect.DeepEqual(imported.Rules[0], rule) {
+		t.Errorf("expected %+v, got %+v", rule, imported.Rules[0])
+	}
+}
+
+func TestReplicationV1Rules(t *testing.T) {
+	v1Rule := `<ID>logs</ID><Prefix>logs/</Prefix><Status>Enabled</Status>` +
+		`<Destination><Bucket>arn:aws:s3:::logs</Bucket><StorageClass>STANDARD_IA</StorageClass></Destination>`
+	replicationXML := `<ReplicationConfiguration><Role>arn:aws:iam::123456789012:role/replication</Role>` +
+		`<Rule>` + v1Rule + `</Rule>` +
+		`<Rule><ID>docs</ID><Prefix></Prefix><Status>Disabled</Status><Destination><Bucket>arn:aws:s3:::docs</Bucket></Destination></Rule>` +
+		`</ReplicationConfiguration>`
+
+	// Rules are read like GetReplication does.
+	replication := &replicationConfiguration{}
+	if e := xml.Unmarshal([]byte(replicationXML), replication); e != nil {
+		t.Fatal(e)
+	}
+	if !replication.Rules[0].isV1() || replication.Rules[0].prefix() != "logs/" {
+		t.Fatalf("unexpected rule %+v", replication.Rules[0])
+	}
+	// Legacy rules have no priorities, they do not conflict.
+	if err := replication.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Removing a rule keeps the other ones as they were read.
+	replication.removeRule("docs")
+	replicationBytes, e := xml.Marshal(replication)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `<ReplicationConfiguration><Role>arn:aws:iam::123456789012:role/replication</Role>` +
+		`<Rule>` + v1Rule + `</Rule></ReplicationConfiguration>`
+	if string(replicationBytes) != expected {
+		t.Errorf("expected %s, got %s", expected, replicationBytes)
+	}
+
+	// Rules with filters cannot be added to legacy rules.
+	rule, err := replicateOptions{ID: "new", ARN: "arn:aws:s3:::new", Priority: 1}.toRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	replication.addRule(rule)
+	if err = replication.validate(); err == nil {
+		t.Errorf("expected an error for legacy rules mixed with rule new")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionClearFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "clear retention of all objects under the prefix",
+		},
+	}
+)
+
+var retentionClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "clear governance retention of objects",
+	Action: mainRetentionClear,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Clear the governance retention of an object.
+     $ {{.HelpName}} s3/mylockedbucket/reports/2019.csv
+
+  2. Clear the governance retention of all objects under a prefix.
+     $ {{.HelpName}} --recursive s3/mylockedbucket/reports/
+`,
+}
+
+// checkRetentionClearSyntax - validate all the passed arguments
+func checkRetentionClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+func mainRetentionClear(ctx *cli.Context) error {
+	console.SetColor("Retention", color.New(color.FgGreen, color.Bold))
+
+	checkRetentionClearSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	return applyToObjects(urlStr, ctx.Bool("recursive"), "clear retention of", func(clnt Client, objectURL string) *probe.Error {
+		// Compliance retention cannot be bypassed, the server rejects it.
+		if err := clnt.SetRetention("", time.Time{}, true); err != nil {
+			return err
+		}
+		printMsg(newRetentionMessage("clear", objectURL, "", time.Time{}))
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionInfoFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "show retention of all objects under the prefix",
+		},
+	}
+)
+
+var retentionInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show retention of objects",
+	Action: mainRetentionInfo,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionInfoFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the retention of an object.
+     $ {{.HelpName}} s3/mylockedbucket/reports/2019.csv
+
+  2. Show the retention of all objects under a prefix.
+     $ {{.HelpName}} --recursive s3/mylockedbucket/records/
+`,
+}
+
+// checkRetentionInfoSyntax - validate all the passed arguments
+func checkRetentionInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+func mainRetentionInfo(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.FgCyan, color.Bold))
+
+	checkRetentionInfoSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	return applyToObjects(urlStr, ctx.Bool("recursive"), "get retention of", func(clnt Client, objectURL string) *probe.Error {
+		mode, retainUntil, err := clnt.GetRetention()
+		if err != nil {
+			return err
+		}
+		printMsg(newRetentionMessage("info", objectURL, mode, retainUntil))
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionFlags = []cli.Flag{}
+)
+
+var retentionCmd = cli.Command{
+	Name:            "retention",
+	Usage:           "manage retention of locked objects",
+	HideHelpCommand: true,
+	Action:          mainRetention,
+	Before:          setGlobalsFromContext,
+	Flags:           append(retentionFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		retentionSetCmd,
+		retentionClearCmd,
+		retentionInfoCmd,
+	},
+}
+
+// mainRetention is the handle for "mc retention" command.
+func mainRetention(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "clear", "info" have their own main.
+}
+
+// retentionMessage container for object retention messages.
+type retentionMessage struct {
+	op          string
+	Status      string     `json:"status"`
+	URL         string     `json:"url"`
+	Mode        string     `json:"mode,omitempty"`
+	RetainUntil *time.Time `json:"retainUntil,omitempty"`
+}
+
+// newRetentionMessage returns a message of the retention of an object.
+func newRetentionMessage(op, urlStr, mode string, retainUntil time.Time) retentionMessage {
+	msg := retentionMessage{op: op, URL: urlStr, Mode: mode}
+	if mode != "" && !retainUntil.IsZero() {
+		retainUntil = retainUntil.Local()
+		msg.RetainUntil = &retainUntil
+	}
+	return msg
+}
+
+func (r retentionMessage) String() string {
+	retention := r.Mode
+	if r.RetainUntil != nil {
+		retention += " until " + r.RetainUntil.Format(printDate)
+	}
+	switch r.op {
+	case "set":
+		return console.Colorize("Retention", fmt.Sprintf("Retention %s set on `%s`.", retention, r.URL))
+	case "clear":
+		return console.Colorize("Retention", fmt.Sprintf("Retention cleared on `%s`.", r.URL))
+	case "info":
+		if r.Mode == "" {
+			retention = "not retained"
+		}
+		return fmt.Sprintf("%s: %s", console.Colorize("URL", r.URL), retention)
+	}
+	return ""
+}
+
+func (r retentionMessage) JSON() string {
+	r.Status = "success"
+	retentionMessageJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(retentionMessageJSONBytes)
+}
@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionSetFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "set retention of all objects under the prefix",
+		},
+		cli.BoolFlag{
+			Name:  "bypass",
+			Usage: "bypass governance retention, to shorten it or change its mode",
+		},
+	}
+)
+
+var retentionSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set retention of objects",
+	Action: mainRetentionSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] MODE VALIDITY TARGET
+
+MODE:
+  governance  objects can be removed or their retention shortened with --bypass
+  compliance  objects cannot be removed and their retention cannot be shortened until it expires
+
+VALIDITY:
+  A number of days or years, e.g. '30d' or '1y'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Retain an object in governance mode for 30 days.
+     $ {{.HelpName}} governance 30d s3/mylockedbucket/reports/2019.csv
+
+  2. Retain all objects under a prefix in compliance mode for 7 years.
+     $ {{.HelpName}} --recursive compliance 7y s3/mylockedbucket/records/
+
+  3. Shorten the governance retention of an object to 1 day.
+     $ {{.HelpName}} --bypass governance 1d s3/mylockedbucket/reports/2019.csv
+`,
+}
+
+// checkRetentionSetSyntax - validate all the passed arguments
+func checkRetentionSetSyntax(ctx *cli.Context) (mode string, retainUntil time.Time) {
+	if len(ctx.Args()) != 3 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	mode, err := parseRetentionMode(ctx.Args().Get(0))
+	fatalIf(err, "Invalid retention mode.")
+	retainUntil, err = parseRetentionValidity(ctx.Args().Get(1), UTCNow())
+	fatalIf(err, "Invalid retention validity.")
+	return mode, retainUntil
+}
+
+func mainRetentionSet(ctx *cli.Context) error {
+	console.SetColor("Retention", color.New(color.FgGreen, color.Bold))
+
+	mode, retainUntil := checkRetentionSetSyntax(ctx)
+
+	urlStr := ctx.Args().Get(2)
+	isBypass := ctx.Bool("bypass")
+	return applyToObjects(urlStr, ctx.Bool("recursive"), "set retention of", func(clnt Client, objectURL string) *probe.Error {
+		if err := clnt.SetRetention(mode, retainUntil, isBypass); err != nil {
+			return err
+		}
+		printMsg(newRetentionMessage("set", objectURL, mode, retainUntil))
+		return nil
+	})
+}
@@ -0,0 +1,191 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6"
+)
+
+const (
+	// Default number of times a failed transfer is retried.
+	defaultMaxRetries = 3
+
+	// Backoff delay before the first retry, doubled on each retry.
+	retryBaseDelay = time.Second
+
+	// Maximum backoff delay between two retries.
+	retryMaxDelay = 30 * time.Second
+)
+
+// List of S3 error codes which are transient.
+var retryableS3Codes = map[string]struct{}{
+	"RequestError":               {},
+	"RequestTimeout":             {},
+	"Throttling":                 {},
+	"ThrottlingException":        {},
+	"RequestLimitExceeded":       {},
+	"RequestThrottled":           {},
+	"InternalError":              {},
+	"SlowDown":                   {},
+	"ServiceUnavailable":         {},
+	"XMinioServerNotInitialized": {},
+}
+
+// List of HTTP status codes which are transient.
+var retryableHTTPStatusCodes = map[int]struct{}{
+	http.StatusTooManyRequests:     {},
+	http.StatusInternalServerError: {},
+	http.StatusBadGateway:          {},
+	http.StatusServiceUnavailable:  {},
+	http.StatusGatewayTimeout:      {},
+}
+
+// isErrRetryable - returns true if the error is transient, such as
+// throttling or network errors, and the failed operation may succeed
+// when it is retried. All other errors are fatal for the object.
+func isErrRetryable(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	e := err.ToGoError()
+	if e == io.ErrUnexpectedEOF {
+		return true
+	}
+	switch v := e.(type) {
+	case minio.ErrorResponse:
+		if _, ok := retryableS3Codes[v.Code]; ok {
+			return true
+		}
+		_, ok := retryableHTTPStatusCodes[v.StatusCode]
+		return ok
+	case *url.Error:
+		if _, ok := v.Err.(net.Error); ok {
+			return true
+		}
+		return strings.Contains(v.Error(), "connection reset") ||
+			strings.Contains(v.Error(), "transport connection broken")
+	case net.Error:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns the backoff delay before retry number attempt,
+// starting at zero. The delay grows exponentially and is jittered so
+// that parallel transfers failing together do not retry together.
+func retryDelay(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		if d := retryBaseDelay << uint(attempt); d < retryMaxDelay {
+			delay = d
+		}
+	}
+	// Wait at least half of the delay.
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryProgress forwards to progress only the data which was not
+// already reported by previous attempts of the same transfer, so
+// that retries do not count the same bytes twice.
+type retryProgress struct {
+	progress io.Reader
+	// Bytes reported by previous attempts.
+	reported int64
+	// Bytes read by the current attempt.
+	current int64
+}
+
+// Read implements io.Reader.
+func (r *retryProgress) Read(p []byte) (n int, err error) {
+	end := atomic.AddInt64(&r.current, int64(len(p)))
+	start := end - int64(len(p))
+	if end <= r.reported {
+		return len(p), nil
+	}
+	if start < r.reported {
+		p = p[r.reported-start:]
+	}
+	if _, err = r.progress.Read(p); err != nil {
+		return 0, err
+	}
+	return int(end - start), nil
+}
+
+// retry prepares for a new attempt.
+func (r *retryProgress) retry() {
+	if current := atomic.SwapInt64(&r.current, 0); current > r.reported {
+		r.reported = current
+	}
+}
+
+// uploadSourceToTargetURLWithRetry - uploads to targetURL from source
+// and retries with a backoff up to maxRetries times if the upload
+// fails with a transient error.
+func uploadSourceToTargetURLWithRetry(ctx context.Context, urls URLs, progress io.Reader, maxRetries int, encKeyDB map[string][]prefixSSEPair) URLs {
+	rp := &retryProgress{progress: progress}
+	for attempt := 0; ; attempt++ {
+		status := uploadSourceToTargetURL(ctx, urls, rp, encKeyDB)
+		if status.Error == nil || attempt >= maxRetries || !isErrRetryable(status.Error) {
+			return status
+		}
+		select {
+		case <-ctx.Done():
+			return status
+		case <-time.After(retryDelay(attempt)):
+		}
+		rp.retry()
+	}
+}
+
+// transferFailuresMessage container for the summary of objects which
+// could not be transferred, even after retries.
+type transferFailuresMessage struct {
+	Status string   `json:"status"`
+	Failed []string `json:"failed"`
+}
+
+// String colorized summary of failed objects.
+func (t transferFailuresMessage) String() string {
+	msg := console.Colorize("TransferFailed", fmt.Sprintf("Failed to transfer %d object(s):", len(t.Failed)))
+	for _, failed := range t.Failed {
+		msg += "\n" + console.Colorize("TransferFailed", fmt.Sprintf("  `%s`", failed))
+	}
+	return msg
+}
+
+// JSON jsonified summary of failed objects.
+func (t transferFailuresMessage) JSON() string {
+	t.Status = "error"
+	transferFailuresMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(transferFailuresMessageBytes)
+}
@@ -0,0 +1,114 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6"
+)
+
+func TestIsErrRetryable(t *testing.T) {
+	testCases := []struct {
+		err       *probe.Error
+		retryable bool
+	}{
+		{nil, false},
+		{probe.NewError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "InternalError", StatusCode: http.StatusInternalServerError}), true},
+		{probe.NewError(minio.ErrorResponse{StatusCode: http.StatusBadGateway}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}), false},
+		{probe.NewError(io.ErrUnexpectedEOF), true},
+		{probe.NewError(errors.New("invalid argument")), false},
+		{probe.NewError(ObjectMissing{}), false},
+	}
+	for i, testCase := range testCases {
+		if retryable := isErrRetryable(testCase.err); retryable != testCase.retryable {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.retryable, retryable)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := 0; attempt < 100; attempt++ {
+		expected := retryMaxDelay
+		if attempt < 5 {
+			expected = retryBaseDelay << uint(attempt)
+		}
+		delay := retryDelay(attempt)
+		if delay < expected/2 || delay > expected {
+			t.Fatalf("Attempt %d: expected delay between %s and %s, got %s", attempt, expected/2, expected, delay)
+		}
+	}
+}
+
+// countingReader counts the bytes reported to it.
+type countingReader struct {
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+func TestRetryProgress(t *testing.T) {
+	progress := &countingReader{}
+	rp := &retryProgress{progress: progress}
+
+	// First attempt fails after 100 bytes.
+	rp.Read(make([]byte, 60))
+	rp.Read(make([]byte, 40))
+	rp.retry()
+
+	// Second attempt fails after 50 bytes.
+	rp.Read(make([]byte, 50))
+	if progress.n != 100 {
+		t.Fatalf("expected 100 bytes reported, got %d", progress.n)
+	}
+	rp.retry()
+
+	// Third attempt transfers all 150 bytes.
+	rp.Read(make([]byte, 80))
+	rp.Read(make([]byte, 70))
+	if progress.n != 150 {
+		t.Fatalf("expected 150 bytes reported, got %d", progress.n)
+	}
+}
+
+func TestIsCopyErrorFatal(t *testing.T) {
+	testCases := []struct {
+		err   *probe.Error
+		fatal bool
+	}{
+		// Transfers still failing after their retries are listed at the end.
+		{probe.NewError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}), false},
+		{probe.NewError(io.ErrUnexpectedEOF), false},
+		{probe.NewError(ObjectMissing{}), false},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}), true},
+		{probe.NewError(errors.New("invalid argument")), true},
+	}
+	for i, testCase := range testCases {
+		if fatal := isCopyErrorFatal(testCase.err); fatal != testCase.fatal {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.fatal, fatal)
+		}
+	}
+}
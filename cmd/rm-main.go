@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -57,12 +58,8 @@ var (
 			Usage: "read object names from STDIN",
 		},
 		cli.StringFlag{
-			Name:  "older-than",
-			Usage: "remove objects older than L days, M hours and N minutes",
-		},
-		cli.StringFlag{
-			Name:  "newer-than",
-			Usage: "remove objects newer than L days, M hours and N minutes",
+			Name:  "version-id",
+			Usage: "permanently remove a specific version or delete marker of the object",
 		},
 	}
 )
@@ -73,7 +70,7 @@ var rmCmd = cli.Command{
 	Usage:  "remove objects",
 	Action: mainRm,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(append(rmFlags, ioFlags...), filterFlags...), sizeFilterFlags...), ageFilterFlags...), reportFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -83,6 +80,7 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+` + filterUnitsHelp + `
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT_KEY: list of comma delimited prefix=secret values
 
@@ -116,18 +114,31 @@ EXAMPLES:
 
   10. Remove an encrypted object from Amazon S3 cloud storage.
       $ {{.HelpName}} --encrypt-key "s3/sql-backups/=32byteslongsecretkeymustbegiven1" s3/sql-backups/1999/old-backup.tgz
+
+  11. Remove all objects older than '90' days recursively from bucket 'jazz-songs' and write a CSV report of removed objects.
+      $ {{.HelpName}} --recursive --force --older-than 90d --report removed.csv s3/jazz-songs/
+
+  12. Remove all objects with ".wav" extension and larger than 100MiB recursively from bucket 'jazz-songs'.
+      $ {{.HelpName}} --recursive --force --include "*.wav" --larger 100MiB s3/jazz-songs/
+
+  13. Permanently remove a specific version of an object from a versioned bucket.
+      $ {{.HelpName}} --version-id "3a6c3e5f-24a1-4ed4-9c0f-a4f5e6f4e2b1" s3/jazz-songs/louis/hello-dolly.mp3
 `,
 }
 
 // Structured message depending on the type of console.
 type rmMessage struct {
-	Status string `json:"status"`
-	Key    string `json:"key"`
-	Size   int64  `json:"size"`
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"versionId,omitempty"`
 }
 
 // Colorized message for console printing.
 func (r rmMessage) String() string {
+	if r.VersionID != "" {
+		return console.Colorize("Remove", fmt.Sprintf("Removing `%s` (versionId=%s).", r.Key, r.VersionID))
+	}
 	return console.Colorize("Remove", fmt.Sprintf("Removing `%s`.", r.Key))
 }
 
@@ -173,13 +184,17 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		fatalIf(errDummy().Trace(),
 			"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 	}
+	if ctx.String("version-id") != "" && (isRecursive || isStdin || ctx.Bool("incomplete") || len(ctx.Args()) != 1) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+			"A version can only be removed from a single object, without --recursive, --stdin or --incomplete.")
+	}
 	if (isRecursive || isStdin) && isNamespaceRemoval && !isDangerous {
 		fatalIf(errDummy().Trace(),
 			"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
 	}
 }
 
-func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeSingle(url string, isIncomplete bool, isFake, isForce bool, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) error {
 	isRecursive := false
 	contents, pErr := statURL(url, isIncomplete, isRecursive, encKeyDB)
 	if pErr != nil {
@@ -196,13 +211,8 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 
 	content := contents[0]
 
-	// Skip objects older than older--than parameter if specified
-	if olderThan != "" && isOlder(content.Time, olderThan) {
-		return nil
-	}
-
-	// Skip objects older than older--than parameter if specified
-	if newerThan != "" && isNewer(content.Time, newerThan) {
+	// Skip objects not selected by filters, which match the base name.
+	if !filter.match(filepath.Base(filepath.FromSlash(url)), content.Size, content.Time) {
 		return nil
 	}
 
@@ -211,7 +221,10 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 		Size: content.Size,
 	})
 
-	if !isFake {
+	start := UTCNow()
+	if isFake {
+		globalTransferReport.add(transferReportEntry{Status: reportSkipped, Target: url, Size: content.Size})
+	} else {
 		targetAlias, targetURL, _ := mustExpandAlias(url)
 		clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 		if pErr != nil {
@@ -224,9 +237,13 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 		close(contentCh)
 		isRemoveBucket := false
 		errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
+		isFailed := false
 		for pErr := range errorCh {
 			if pErr != nil {
 				errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
+				globalTransferReport.add(transferReportEntry{Status: reportFailed, Target: url, Size: content.Size,
+					Duration: time.Since(start).Seconds(), Error: pErr.ToGoError().Error()})
+				isFailed = true
 				switch pErr.ToGoError().(type) {
 				case PathInsufficientPermission:
 					// Ignore Permission error.
@@ -235,11 +252,60 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 				return exitStatus(globalErrorExitStatus)
 			}
 		}
+		if !isFailed {
+			globalTransferReport.add(transferReportEntry{Status: reportRemoved, Target: url, Size: content.Size,
+				Duration: time.Since(start).Seconds()})
+		}
 	}
 	return nil
 }
 
-func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+// removeVersion - permanently removes a version of an object, delete
+// markers are removed as well since they are not stat'ed first.
+func removeVersion(url, versionID string, isFake bool) error {
+	printMsg(rmMessage{
+		Key:       url,
+		VersionID: versionID,
+	})
+
+	if isFake {
+		globalTransferReport.add(transferReportEntry{Status: reportSkipped, Target: url})
+		return nil
+	}
+
+	start := UTCNow()
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(url), "Invalid argument `"+url+"`.")
+		return exitStatus(globalErrorExitStatus) // End of journey.
+	}
+	if pErr = clnt.RemoveVersion(versionID); pErr != nil {
+		errorIf(pErr.Trace(url, versionID), "Failed to remove version `"+versionID+"` of `"+url+"`.")
+		globalTransferReport.add(transferReportEntry{Status: reportFailed, Target: url,
+			Duration: time.Since(start).Seconds(), Error: pErr.ToGoError().Error()})
+		return exitStatus(globalErrorExitStatus)
+	}
+	globalTransferReport.add(transferReportEntry{Status: reportRemoved, Target: url,
+		Duration: time.Since(start).Seconds()})
+	return nil
+}
+
+// removeErrorPath returns the path of the object or file whose removal
+// failed with err, it is empty if the error does not name it.
+func removeErrorPath(err *probe.Error) string {
+	switch e := err.ToGoError().(type) {
+	case ObjectRemoveFailed:
+		return e.Path
+	case PathInsufficientPermission:
+		return e.Path
+	case *os.PathError:
+		return e.Path
+	}
+	return ""
+}
+
+func removeRecursive(url string, isIncomplete bool, isFake bool, filter *objectFilter, encKeyDB map[string][]prefixSSEPair) error {
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
@@ -251,30 +317,59 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 
 	errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
 
+	// Objects are removed in batches and only failures are returned,
+	// objects sent for removal are reported once all failures are
+	// known. Sizes are kept by path for the report.
+	var sentPaths []string
+	sentSizes := make(map[string]int64)
+	var retErr error
+
+	// reportRemoveError reports a failed removal, it returns false if
+	// the error stops the removal.
+	reportRemoveError := func(pErr *probe.Error) bool {
+		target := url
+		path := removeErrorPath(pErr)
+		if path != "" {
+			target = targetAlias + path
+		}
+		size := sentSizes[path]
+		delete(sentSizes, path)
+		errorIf(pErr.Trace(target), "Failed to remove `"+target+"`.")
+		globalTransferReport.add(transferReportEntry{Status: reportFailed, Target: target, Size: size, Error: pErr.ToGoError().Error()})
+		switch pErr.ToGoError().(type) {
+		case PathInsufficientPermission:
+			// Ignore Permission error.
+			return true
+		}
+		retErr = exitStatus(globalErrorExitStatus)
+		return false
+	}
+
 	isRecursive := true
+loop:
 	for content := range clnt.List(isRecursive, isIncomplete, DirLast) {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(url), "Failed to remove `"+url+"` recursively.")
+			globalTransferReport.add(transferReportEntry{Status: reportFailed, Target: url, Error: content.Err.ToGoError().Error()})
 			switch content.Err.ToGoError().(type) {
 			case PathInsufficientPermission:
 				// Ignore Permission error.
 				continue
 			}
-			close(contentCh)
-			return exitStatus(globalErrorExitStatus)
+			retErr = exitStatus(globalErrorExitStatus)
+			break loop
 		}
 		urlString := content.URL.Path
 
-		if !content.Time.IsZero() {
-			// Skip objects older than --older-than parameter if specified
-			if olderThan != "" && isOlder(content.Time, olderThan) {
-				continue
-			}
-
-			// Skip objects newer than --newer-than parameter if specified
-			if newerThan != "" && isNewer(content.Time, newerThan) {
+		// Skip objects not selected by filters, prefixes without
+		// time are only matched by name.
+		name := filterName(clnt.GetURL().Path, urlString)
+		if content.Time.IsZero() {
+			if !filter.matchName(name) {
 				continue
 			}
+		} else if !filter.match(name, content.Size, content.Time) {
+			continue
 		}
 
 		printMsg(rmMessage{
@@ -282,38 +377,41 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 			Size: content.Size,
 		})
 
-		if !isFake {
-			sent := false
-			for !sent {
-				select {
-				case contentCh <- content:
-					sent = true
-				case pErr := <-errorCh:
-					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
-					switch pErr.ToGoError().(type) {
-					case PathInsufficientPermission:
-						// Ignore Permission error.
-						continue
-					}
-					close(contentCh)
-					return exitStatus(globalErrorExitStatus)
+		if isFake {
+			globalTransferReport.add(transferReportEntry{Status: reportSkipped, Target: targetAlias + urlString, Size: content.Size})
+			continue
+		}
+
+		sent := false
+		for !sent {
+			select {
+			case contentCh <- content:
+				sent = true
+				if globalTransferReport != nil {
+					sentPaths = append(sentPaths, urlString)
+					sentSizes[urlString] = content.Size
+				}
+			case pErr, ok := <-errorCh:
+				if !ok || !reportRemoveError(pErr) {
+					break loop
 				}
 			}
 		}
 	}
 
+	// Receive the failures of the objects already sent, even when
+	// the removal is stopped, the other ones are removed.
 	close(contentCh)
 	for pErr := range errorCh {
-		errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
-		switch pErr.ToGoError().(type) {
-		case PathInsufficientPermission:
-			// Ignore Permission error.
-			continue
+		reportRemoveError(pErr)
+	}
+	for _, path := range sentPaths {
+		if size, ok := sentSizes[path]; ok {
+			globalTransferReport.add(transferReportEntry{Status: reportRemoved, Target: targetAlias + path, Size: size})
 		}
-		return exitStatus(globalErrorExitStatus)
 	}
 
-	return nil
+	return retErr
 }
 
 // main for rm command.
@@ -330,21 +428,29 @@ func mainRm(ctx *cli.Context) error {
 	isRecursive := ctx.Bool("recursive")
 	isFake := ctx.Bool("fake")
 	isStdin := ctx.Bool("stdin")
-	olderThan := ctx.String("older-than")
-	newerThan := ctx.String("newer-than")
 	isForce := ctx.Bool("force")
+	versionID := ctx.String("version-id")
+
+	filter, err := newObjectFilter(getFilterFlags(ctx))
+	fatalIf(err, "Unable to parse filters.")
 
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
+	err = openTransferReport(ctx.String("report"), false)
+	fatalIf(err, "Unable to create report.")
+	defer globalTransferReport.Close()
+
 	var rerr error
 	var e error
 	// Support multiple targets.
 	for _, url := range ctx.Args() {
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+		if versionID != "" {
+			e = removeVersion(url, versionID, isFake)
+		} else if isRecursive {
+			e = removeRecursive(url, isIncomplete, isFake, filter, encKeyDB)
 		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, isIncomplete, isFake, isForce, filter, encKeyDB)
 		}
 
 		if rerr == nil {
@@ -360,9 +466,9 @@ func mainRm(ctx *cli.Context) error {
 	for scanner.Scan() {
 		url := scanner.Text()
 		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+			e = removeRecursive(url, isIncomplete, isFake, filter, encKeyDB)
 		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, isIncomplete, isFake, isForce, filter, encKeyDB)
 		}
 
 		if rerr == nil {
@@ -0,0 +1,123 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// removeHandler lists objects and fails to remove the ones in denied.
+type removeHandler struct {
+	objects []string
+	denied  map[string]bool
+}
+
+func (h removeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch {
+	case len(query["location"]) > 0:
+		w.Write([]byte(`<LocationConstraint xmlns="http://doc.s3.amazonaws.com/2006-03-01"></LocationConstraint>`))
+	case r.Method == http.MethodPost && len(query["delete"]) > 0:
+		var request struct {
+			Objects []struct {
+				Key string `xml:"Key"`
+			} `xml:"Object"`
+		}
+		if e := xml.NewDecoder(r.Body).Decode(&request); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response := "<DeleteResult>"
+		for _, object := range request.Objects {
+			if h.denied[object.Key] {
+				response += "<Error><Key>" + object.Key + "</Key><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>"
+			}
+		}
+		w.Write([]byte(response + "</DeleteResult>"))
+	case r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet:
+		response := "<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated>"
+		for _, object := range h.objects {
+			response += "<Contents><Key>" + object + "</Key><Size>1</Size><LastModified>2019-01-01T00:00:00.000Z</LastModified></Contents>"
+		}
+		w.Write([]byte(response + "</ListBucketResult>"))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestRemoveRecursiveReport(t *testing.T) {
+	server := httptest.NewServer(removeHandler{
+		objects: []string{"a", "b", "c"},
+		denied:  map[string]bool{"b": true},
+	})
+	defer server.Close()
+	os.Setenv("MC_HOST_rmtest", strings.Replace(server.URL, "http://", "http://WLGDGYAQYIGI833EV05A:BYvgJM101sHngl2uzjXS@", 1))
+	defer os.Unsetenv("MC_HOST_rmtest")
+
+	dir, e := ioutil.TempDir("", "mc-rm-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	reportPath := filepath.Join(dir, "report.json")
+	if err := openTransferReport(reportPath, false); err != nil {
+		t.Fatal(err)
+	}
+	filter, err := newObjectFilter(map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e = removeRecursive("rmtest/bucket/", false, false, filter, nil); e == nil {
+		t.Error("Expected an error for a failed removal, got success")
+	}
+	globalTransferReport.Close()
+	globalTransferReport = nil
+
+	f, e := os.Open(reportPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry transferReportEntry
+		if e = json.Unmarshal(scanner.Bytes(), &entry); e != nil {
+			t.Fatal(e)
+		}
+		entries = append(entries, entry.Status+" "+entry.Target)
+	}
+	sort.Strings(entries)
+
+	// Each object is reported once with its outcome.
+	expected := []string{"failed rmtest/bucket/b", "removed rmtest/bucket/a", "removed rmtest/bucket/c"}
+	if strings.Join(entries, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected report entries %v, got %v", expected, entries)
+	}
+}
@@ -62,11 +62,31 @@ func (b bySessionWhen) Less(i, j int) bool { return b[i].Header.When.Before(b[j]
 // sessionExecute - run a given session.
 func sessionExecute(s *sessionV8) {
 	switch s.Header.CommandType {
-	case "cp":
+	case "cp", "mv":
 		sseKeys := s.Header.CommandStringFlags["encrypt-key"]
 		sseServer := s.Header.CommandStringFlags["encrypt"]
 		encKeyDB, _ := parseAndValidateEncryptionKeys(sseKeys, sseServer)
+		err := setBandwidthLimits(s.Header.CommandStringFlags["limit-upload"], s.Header.CommandStringFlags["limit-download"])
+		fatalIf(err, "Unable to parse bandwidth limits.")
+		err = openTransferReport(s.Header.CommandStringFlags["report"], true)
+		fatalIf(err, "Unable to open report.")
 		doCopySession(s, encKeyDB)
+		globalTransferReport.Close()
+	case "mirror":
+		sseKeys := s.Header.CommandStringFlags["encrypt-key"]
+		sseServer := s.Header.CommandStringFlags["encrypt"]
+		encKeyDB, _ := parseAndValidateEncryptionKeys(sseKeys, sseServer)
+		err := setBandwidthLimits(s.Header.CommandStringFlags["limit-upload"], s.Header.CommandStringFlags["limit-download"])
+		fatalIf(err, "Unable to parse bandwidth limits.")
+		err = openTransferReport(s.Header.CommandStringFlags["report"], true)
+		fatalIf(err, "Unable to open report.")
+		doMirrorSession(s, encKeyDB)
+		globalTransferReport.Close()
+	case "encrypt rotate":
+		err := openTransferReport(s.Header.CommandStringFlags["report"], true)
+		fatalIf(err, "Unable to open report.")
+		doRotateSession(s)
+		globalTransferReport.Close()
 	}
 }
 
@@ -0,0 +1,269 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Number of example values shown per column.
+const describeExamples = 3
+
+// columnDescription is the inferred schema of a column.
+type columnDescription struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	NullRatio float64  `json:"nullRatio"`
+	Examples  []string `json:"examples"`
+
+	nulls int
+	types map[string]bool
+}
+
+// describeMessage container for the schema of an object.
+type describeMessage struct {
+	Status  string              `json:"status"`
+	URL     string              `json:"url"`
+	Records int                 `json:"records"`
+	Columns []columnDescription `json:"columns"`
+}
+
+// String colorized describe message.
+func (d describeMessage) String() string {
+	var b strings.Builder
+	b.WriteString(console.Colorize("DescribeURL", d.URL) + ": " +
+		console.Colorize("DescribeRecords", fmt.Sprintf("%d records sampled", d.Records)) + "\n")
+	if len(d.Columns) == 0 {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	rows := [][]string{{"COLUMN", "TYPE", "NULLS", "EXAMPLES"}}
+	for _, col := range d.Columns {
+		rows = append(rows, []string{col.Name, col.Type,
+			strconv.FormatFloat(col.NullRatio*100, 'f', 1, 64) + "%",
+			strings.Join(col.Examples, ", ")})
+	}
+	widths := make([]int, 3)
+	for _, row := range rows {
+		for i := range widths {
+			if len(row[i]) > widths[i] {
+				widths[i] = len(row[i])
+			}
+		}
+	}
+	header := newPrettyTable("  ",
+		Field{"", widths[0]},
+		Field{"", widths[1]},
+		Field{"", widths[2]},
+		Field{"", -1},
+	)
+	b.WriteString("  " + console.Colorize("Headers", header.buildRow(rows[0]...)) + "\n")
+	table := newPrettyTable("  ",
+		Field{"DescribeColumn", widths[0]},
+		Field{"DescribeType", widths[1]},
+		Field{"DescribeNulls", widths[2]},
+		Field{"DescribeExamples", -1},
+	)
+	for _, row := range rows[1:] {
+		b.WriteString("  " + table.buildRow(row...) + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified describe message.
+func (d describeMessage) JSON() string {
+	d.Status = "success"
+	msgBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// describeRecords infers the columns of a stream of JSON records, in
+// the order in which they first appear.
+func describeRecords(r io.Reader) (records int, columns []columnDescription, err error) {
+	index := make(map[string]int)
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	for {
+		t, e := d.Token()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return 0, nil, e
+		}
+		if t != json.Delim('{') {
+			return 0, nil, errors.New("unexpected record in select output")
+		}
+		records++
+		seen := make(map[string]bool)
+		for d.More() {
+			t, e = d.Token()
+			if e != nil {
+				return 0, nil, e
+			}
+			name, _ := t.(string)
+			var v interface{}
+			if e = d.Decode(&v); e != nil {
+				return 0, nil, e
+			}
+			i, ok := index[name]
+			if !ok {
+				i = len(columns)
+				index[name] = i
+				// Records sampled before the column showed up lack it.
+				columns = append(columns, columnDescription{Name: name, nulls: records - 1, types: make(map[string]bool)})
+			}
+			seen[name] = true
+			columns[i].add(v)
+		}
+		if _, e = d.Token(); e != nil {
+			return 0, nil, e
+		}
+		for name, i := range index {
+			if !seen[name] {
+				columns[i].nulls++
+			}
+		}
+	}
+	for i := range columns {
+		columns[i].Type = mergeColumnTypes(columns[i].types)
+		columns[i].NullRatio = float64(columns[i].nulls) / float64(records)
+	}
+	return records, columns, nil
+}
+
+// add accounts a sampled value of the column.
+func (c *columnDescription) add(v interface{}) {
+	typ := inferValueType(v)
+	if typ == "" {
+		c.nulls++
+		return
+	}
+	c.types[typ] = true
+	if len(c.Examples) >= describeExamples {
+		return
+	}
+	example, ok := v.(string)
+	if !ok {
+		b, _ := json.Marshal(v)
+		example = string(b)
+	}
+	for _, e := range c.Examples {
+		if e == example {
+			return
+		}
+	}
+	c.Examples = append(c.Examples, example)
+}
+
+// inferValueType returns the type of a decoded JSON value, strings are
+// inspected since CSV input is always returned as strings. Empty
+// values return "".
+func inferValueType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return "bool"
+	case json.Number:
+		if _, e := v.Int64(); e == nil {
+			return "int"
+		}
+		return "float"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return ""
+		}
+		if _, e := strconv.ParseInt(s, 10, 64); e == nil {
+			return "int"
+		}
+		if _, e := strconv.ParseFloat(s, 64); e == nil {
+			return "float"
+		}
+		if strings.EqualFold(s, "true") || strings.EqualFold(s, "false") {
+			return "bool"
+		}
+		if _, e := time.Parse(time.RFC3339Nano, s); e == nil {
+			return "timestamp"
+		}
+		if _, e := time.Parse("2006-01-02", s); e == nil {
+			return "timestamp"
+		}
+	}
+	return "string"
+}
+
+// mergeColumnTypes returns a single type for all sampled types of a
+// column, integers widen to floats and other mixes are listed.
+func mergeColumnTypes(types map[string]bool) string {
+	if types["int"] && types["float"] {
+		delete(types, "int")
+	}
+	var names []string
+	for name := range types {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "null"
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+// sqlDescribe samples the first records of an object and prints the
+// inferred columns.
+func sqlDescribe(targetURL string, sample int, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts) *probe.Error {
+	alias, _, _, err := expandAlias(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	targetClnt, err := newClient(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+
+	// JSON output keeps column names and the types of JSON input.
+	selOpts.OutputSerOpts = map[string]map[string]string{"json": {recordDelimiterType: "\n"}}
+	expression := fmt.Sprintf("SELECT * FROM S3Object LIMIT %d", sample)
+	reader, err := targetClnt.Select(expression, getSSE(targetURL, encKeyDB[alias]), selOpts)
+	if err != nil {
+		return err.Trace(targetURL, expression)
+	}
+	defer reader.Close()
+
+	records, columns, e := describeRecords(reader)
+	if e != nil {
+		return probe.NewError(e).Trace(targetURL)
+	}
+	printMsg(describeMessage{URL: targetURL, Records: records, Columns: columns})
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDescribeRecords(t *testing.T) {
+	input := `{"id":"1","name":"alice","score":"3.5","joined":"2019-01-02"}
+{"id":"2","name":"","score":"4","joined":"2019-03-04T10:00:00Z","tags":[1]}
+{"id":"3","name":"carol","score":"x","nested":{"a":true}}
+{"id":"3","name":"carol","score":1.5}
+`
+	records, columns, err := describeRecords(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records != 4 {
+		t.Fatalf("expected 4 records, got %d", records)
+	}
+	expected := []struct {
+		name      string
+		typ       string
+		nullRatio float64
+		examples  []string
+	}{
+		{"id", "int", 0, []string{"1", "2", "3"}},
+		{"name", "string", 0.25, []string{"alice", "carol"}},
+		{"score", "float|string", 0, []string{"3.5", "4", "x"}},
+		{"joined", "timestamp", 0.5, []string{"2019-01-02", "2019-03-04T10:00:00Z"}},
+		{"tags", "array", 0.75, []string{"[1]"}},
+		{"nested", "object", 0.75, []string{`{"a":true}`}},
+	}
+	if len(columns) != len(expected) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len(columns))
+	}
+	for i, e := range expected {
+		col := columns[i]
+		if col.Name != e.name || col.Type != e.typ || col.NullRatio != e.nullRatio || !reflect.DeepEqual(col.Examples, e.examples) {
+			t.Errorf("Test %d: expected %v, got %s %s %v %v", i+1, e, col.Name, col.Type, col.NullRatio, col.Examples)
+		}
+	}
+
+	if _, _, err = describeRecords(strings.NewReader(`[1,2]`)); err == nil {
+		t.Fatal("expected an error for non-object records")
+	}
+}
@@ -25,13 +25,16 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
-	"github.com/minio/minio/pkg/mimedb"
+	minio "github.com/minio/minio-go/v6"
 )
 
 var (
@@ -52,6 +55,10 @@ var (
 			Name:  "json-input",
 			Usage: "json input serialization option",
 		},
+		cli.BoolFlag{
+			Name:  "parquet-input",
+			Usage: "parquet input serialization",
+		},
 		cli.StringFlag{
 			Name:  "compression",
 			Usage: "input compression type",
@@ -68,6 +75,24 @@ var (
 			Name:  "json-output",
 			Usage: "json output serialization option",
 		},
+		cli.BoolFlag{
+			Name:  "describe",
+			Usage: "infer column names and types from sampled records",
+		},
+		cli.IntFlag{
+			Name:  "sample",
+			Usage: "number of records sampled by --describe",
+			Value: 100,
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Usage: "number of objects queried in parallel",
+			Value: 4,
+		},
+		cli.BoolFlag{
+			Name:  "prefix-output",
+			Usage: "tag each record with the object it was read from",
+		},
 	}
 )
 
@@ -119,6 +144,20 @@ EXAMPLES:
      $ {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
                      --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
                      --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Run a query on an object in parquet format, objects with a .parquet extension
+     are detected automatically.
+     $ {{.HelpName}} --parquet-input --query "select count(*) from S3Object" myminio/datalake/2019/sales
+
+  8. Run a query on a local file, the query is evaluated by mc itself.
+     $ {{.HelpName}} --query "select s.device_id from S3Object s where s.uptime > 3600" ~/iot-devices/data.csv
+
+  9. Describe the columns of all objects under a prefix, sampling their first 1000 records.
+     $ {{.HelpName}} --describe --sample 1000 --recursive myminio/iot-devices/
+
+  10. Query all objects under a prefix, 8 at a time, tagging each record with its object.
+      A summary of the bytes scanned, processed and returned per object and in total is printed after the records.
+      $ {{.HelpName}} --recursive --parallel 8 --prefix-output --query "select * from S3Object" myminio/iot-devices/
 `,
 }
 
@@ -256,8 +295,9 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 
 	csvType := ctx.IsSet("csv-input")
 	jsonType := ctx.IsSet("json-input")
-	if csvType && jsonType {
-		fatalIf(errInvalidArgument(), "Only one of --csv-input or --json-input can be specified as input serialization option")
+	parquetType := ctx.Bool("parquet-input")
+	if (csvType && jsonType) || (parquetType && (csvType || jsonType)) {
+		fatalIf(errInvalidArgument(), "Only one of --csv-input, --json-input or --parquet-input can be specified as input serialization option")
 	}
 
 	if icsv != "" {
@@ -272,6 +312,9 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 		fatalIf(err, "Invalid serialization option(s) specified for --json-input flag")
 		m["json"] = kv
 	}
+	if parquetType {
+		m["parquet"] = map[string]string{}
+	}
 
 	return m
 }
@@ -357,7 +400,9 @@ func getCSVOutputHeaders(ctx *cli.Context, url string, encKeyDB map[string][]pre
 	}
 
 	hdrStr := ctx.String("csv-output-header")
-	if hdrStr == "" && isSelectAll(query) {
+	// parquet objects have no header line to read
+	isParquet := ctx.Bool("parquet-input") || strings.HasSuffix(url, ".parquet")
+	if hdrStr == "" && isSelectAll(query) && !isParquet {
 		// attempt to get the first line of csv as header
 		if hdrs, err := getCSVHeader(url, encKeyDB); err == nil {
 			return hdrs
@@ -372,10 +417,14 @@ func getSQLOpts(ctx *cli.Context, csvHdrs []string) (s SelectObjectOpts) {
 	is := getInputSerializationOpts(ctx)
 	os := getOutputSerializationOpts(ctx, csvHdrs)
 
-	return SelectObjectOpts{
+	s = SelectObjectOpts{
 		InputSerOpts:  is,
 		OutputSerOpts: os,
 	}
+	if c := ctx.String("compression"); c != "" {
+		s.CompressionType = minio.SelectCompressionType(strings.ToUpper(c))
+	}
+	return s
 }
 
 func isCSVOrJSON(inOpts map[string]map[string]string) bool {
@@ -388,30 +437,40 @@ func isCSVOrJSON(inOpts map[string]map[string]string) bool {
 	return false
 }
 
-func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr bool) *probe.Error {
+// sqlSelect runs the query on an object and writes the records to out,
+// returning the stats of the query.
+func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, out *sqlOutput) (sqlStatsMessage, *probe.Error) {
+	stats := sqlStatsMessage{URL: targetURL}
 	alias, _, _, err := expandAlias(targetURL)
 	if err != nil {
-		return err.Trace(targetURL)
+		return stats, err.Trace(targetURL)
 	}
 
 	targetClnt, err := newClient(targetURL)
 	if err != nil {
-		return err.Trace(targetURL)
+		return stats, err.Trace(targetURL)
 	}
 
 	sseKey := getSSE(targetURL, encKeyDB[alias])
 	outputer, err := targetClnt.Select(expression, sseKey, selOpts)
 	if err != nil {
-		return err.Trace(targetURL, expression)
+		return stats, err.Trace(targetURL, expression)
 	}
 	defer outputer.Close()
 
-	// write csv header to stdout
-	if len(csvHdrs) > 0 && writeHdr {
-		fmt.Println(strings.Join(csvHdrs, ","))
+	input := selectObjectInputOpts(selOpts, targetClnt.GetURL().Path)
+	w := out.recordWriter(targetURL, selectObjectOutputOpts(selOpts, input))
+	n, e := io.Copy(w, outputer)
+	if e == nil {
+		e = w.Flush()
+	}
+	stats.BytesReturned = n
+	// Stats are sent by the server at the end of the results.
+	if s, ok := outputer.(interface{ Stats() *minio.StatsMessage }); ok {
+		stats.BytesScanned = s.Stats().BytesScanned
+		stats.BytesProcessed = s.Stats().BytesProcessed
 	}
-	_, e := io.Copy(os.Stdout, outputer)
-	return probe.NewError(e)
+	return stats, probe.NewError(e)
 }
 
 func validateOpts(selOpts SelectObjectOpts, url string) {
@@ -419,6 +478,9 @@ func validateOpts(selOpts SelectObjectOpts, url string) {
 	if strings.HasSuffix(targetURL, ".parquet") && isCSVOrJSON(selOpts.InputSerOpts) {
 		fatalIf(errInvalidArgument(), "Input serialization flags --csv-input and --json-input cannot be used for object in .parquet format")
 	}
+	if _, ok := selOpts.InputSerOpts["parquet"]; ok && selOpts.CompressionType != "" && selOpts.CompressionType != minio.SelectCompressionNONE {
+		fatalIf(errInvalidArgument(), "Compressed objects are not supported with --parquet-input")
+	}
 }
 
 // validate args and optionally fetch the csv header of query object
@@ -435,57 +497,140 @@ func checkSQLSyntax(ctx *cli.Context) {
 	if !ctx.Args().Present() {
 		cli.ShowCommandHelpAndExit(ctx, "sql", 1) // last argument is exit code.
 	}
+	if ctx.Bool("describe") {
+		for _, flag := range []string{"query", "csv-output", "csv-output-header", "json-output"} {
+			if ctx.IsSet(flag) {
+				fatalIf(errInvalidArgument(), "--describe cannot be used with --"+flag+".")
+			}
+		}
+		if ctx.Int("sample") <= 0 {
+			fatalIf(errInvalidArgument(), "--sample must be a positive number.")
+		}
+	}
+	if ctx.Int("parallel") <= 0 {
+		fatalIf(errInvalidArgument(), "--parallel must be a positive number.")
+	}
+}
+
+// runSQL runs the query on an object, or describes its columns with --describe.
+func runSQL(ctx *cli.Context, url, query string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, out *sqlOutput) (sqlStatsMessage, *probe.Error) {
+	if ctx.Bool("describe") {
+		return sqlStatsMessage{URL: url}, sqlDescribe(url, ctx.Int("sample"), encKeyDB, selOpts)
+	}
+	return sqlSelect(url, query, encKeyDB, selOpts, out)
+}
+
+// listSQLTargets sends the objects to query on the returned channel,
+// folders are expanded to the objects in a format supported by select.
+func listSQLTargets(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) <-chan string {
+	targetCh := make(chan string)
+	selOpts := SelectObjectOpts{InputSerOpts: getInputSerializationOpts(ctx)}
+	go func() {
+		defer close(targetCh)
+		for _, url := range ctx.Args() {
+			if !isAliasURLDir(url, encKeyDB) {
+				targetCh <- url
+				continue
+			}
+			targetAlias, targetURL, _ := mustExpandAlias(url)
+			clnt, err := newClientFromAlias(targetAlias, targetURL)
+			if err != nil {
+				errorIf(err.Trace(url), "Unable to initialize target `"+url+"`.")
+				continue
+			}
+			for content := range clnt.List(ctx.Bool("recursive"), false, DirNone) {
+				if content.Err != nil {
+					errorIf(content.Err.Trace(url), "Unable to list on target `"+url+"`.")
+					continue
+				}
+				if isSelectableObject(content.URL.Path, selOpts) {
+					targetCh <- targetAlias + content.URL.Path
+				}
+			}
+		}
+	}()
+	return targetCh
 }
 
 // mainSQL is the main entry point for sql command.
 func mainSQL(ctx *cli.Context) error {
-	var (
-		csvHdrs []string
-		selOpts SelectObjectOpts
-		query   string
-	)
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
 	// validate sql input arguments.
 	checkSQLSyntax(ctx)
-	// extract URLs.
-	URLs := ctx.Args()
-	writeHdr := true
-	for _, url := range URLs {
-		if !isAliasURLDir(url, encKeyDB) {
-			if writeHdr {
-				query, csvHdrs, selOpts = getAndValidateArgs(ctx, encKeyDB, url)
-			}
-			errorIf(sqlSelect(url, query, encKeyDB, selOpts, csvHdrs, writeHdr).Trace(url), "Unable to run sql")
-			writeHdr = false
-			continue
+
+	// Set colors for --describe.
+	console.SetColor("DescribeURL", color.New(color.FgCyan, color.Bold))
+	console.SetColor("DescribeRecords", color.New(color.FgWhite))
+	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
+	console.SetColor("DescribeColumn", color.New(color.FgCyan))
+	console.SetColor("DescribeType", color.New(color.FgYellow))
+	console.SetColor("DescribeNulls", color.New(color.FgWhite))
+	console.SetColor("DescribeExamples", color.New(color.FgWhite))
+
+	targetCh := listSQLTargets(ctx, encKeyDB)
+	first, ok := <-targetCh
+	if !ok {
+		return nil
+	}
+	// Options and the csv header are taken from the first object.
+	query, csvHdrs, selOpts := getAndValidateArgs(ctx, encKeyDB, first)
+	out := &sqlOutput{w: os.Stdout, prefix: ctx.Bool("prefix-output")}
+	if len(csvHdrs) > 0 {
+		if out.prefix {
+			csvHdrs = append([]string{"object"}, csvHdrs...)
 		}
-		targetAlias, targetURL, _ := mustExpandAlias(url)
-		clnt, err := newClientFromAlias(targetAlias, targetURL)
-		if err != nil {
-			errorIf(err.Trace(url), "Unable to initialize target `"+url+"`.")
-			continue
+		fmt.Println(strings.Join(csvHdrs, ","))
+	}
+
+	jobs := make(chan string)
+	go func() {
+		defer close(jobs)
+		jobs <- first
+		for url := range targetCh {
+			jobs <- url
 		}
+	}()
 
-		for content := range clnt.List(ctx.Bool("recursive"), false, DirNone) {
-			if content.Err != nil {
-				errorIf(content.Err.Trace(url), "Unable to list on target `"+url+"`.")
-				continue
-			}
-			if writeHdr {
-				query, csvHdrs, selOpts = getAndValidateArgs(ctx, encKeyDB, targetAlias+content.URL.Path)
-			}
-			contentType := mimedb.TypeByExtension(filepath.Ext(content.URL.Path))
-			for _, cTypeSuffix := range supportedContentTypes {
-				if strings.Contains(contentType, cTypeSuffix) {
-					errorIf(sqlSelect(targetAlias+content.URL.Path, query,
-						encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		summary []sqlStatsMessage
+	)
+	for i := 0; i < ctx.Int("parallel"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				stats, err := runSQL(ctx, url, query, encKeyDB, selOpts, out)
+				if err != nil {
+					errorIf(err.Trace(url), "Unable to run sql")
+					stats.Status = "error"
 				}
-				writeHdr = false
+				mu.Lock()
+				summary = append(summary, stats)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Print the stats of each object and their total when several
+	// were queried, after all the records are written.
+	if len(summary) > 1 && !ctx.Bool("describe") {
+		sort.Slice(summary, func(i, j int) bool { return summary[i].URL < summary[j].URL })
+		total := sqlStatsMessage{URL: fmt.Sprintf("Total (%d objects)", len(summary))}
+		for _, stats := range summary {
+			printMsg(stats)
+			if stats.Status != "error" {
+				total.BytesScanned += stats.BytesScanned
+				total.BytesProcessed += stats.BytesProcessed
+				total.BytesReturned += stats.BytesReturned
 			}
 		}
+		printMsg(total)
 	}
 
 	// Done.
@@ -17,8 +17,11 @@
 package cmd
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+
+	minio "github.com/minio/minio-go/v6"
 )
 
 var testParseKVArgsCases = []struct {
@@ -138,3 +141,44 @@ func TestParseSerializationOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestSQLRecordWriter(t *testing.T) {
+	testCases := []struct {
+		prefix   bool
+		output   minio.SelectObjectOutputSerialization
+		chunks   []string
+		expected string
+	}{
+		{false, minio.SelectObjectOutputSerialization{CSV: &minio.CSVOutputOptions{}},
+			[]string{"a,b\nc", ",d\n", "e"}, "a,b\nc,d\ne"},
+		{true, minio.SelectObjectOutputSerialization{CSV: &minio.CSVOutputOptions{RecordDelimiter: "|", FieldDelimiter: ";"}},
+			[]string{"a;b|c", ";d|"}, "\"x;y\";a;b|\"x;y\";c;d|"},
+		{true, minio.SelectObjectOutputSerialization{JSON: &minio.JSONOutputOptions{RecordDelimiter: "\n"}},
+			[]string{`{"a":1}` + "\n{", "}\n"}, `{"_object":"x;y","a":1}` + "\n" + `{"_object":"x;y"}` + "\n"},
+		// Delimiters within quoted fields do not end records.
+		{true, minio.SelectObjectOutputSerialization{CSV: &minio.CSVOutputOptions{FieldDelimiter: ";"}},
+			[]string{"a;\"b\n", "c\"\"\n\";d\ne\n"}, "\"x;y\";a;\"b\nc\"\"\n\";d\n\"x;y\";e\n"},
+		{true, minio.SelectObjectOutputSerialization{CSV: &minio.CSVOutputOptions{RecordDelimiter: "\r\n", FieldDelimiter: ";", QuoteCharacter: "'", QuoteEscapeCharacter: "\\"}},
+			[]string{"'a\\'\r\n", "b';c\r", "\nd\r\n"}, "'x;y';'a\\'\r\nb';c\r\n'x;y';d\r\n"},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		out := &sqlOutput{w: &buf, prefix: testCase.prefix}
+		w := out.recordWriter("x;y", testCase.output)
+		for _, chunk := range testCase.chunks {
+			if _, err := w.Write([]byte(chunk)); err != nil {
+				t.Fatal(err)
+			}
+			// Only complete records are written.
+			if !strings.HasSuffix(buf.String(), string(w.delim)) && buf.Len() > 0 {
+				t.Fatalf("Test %d: partial record written: %q", i+1, buf.String())
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, buf.String())
+		}
+	}
+}
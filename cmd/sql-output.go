@@ -0,0 +1,234 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// sqlOutput merges the records of queries running in parallel, records
+// are only written as a whole so that they never interleave.
+type sqlOutput struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix bool
+}
+
+// recordWriter returns a writer for the select results of an object.
+func (o *sqlOutput) recordWriter(url string, output minio.SelectObjectOutputSerialization) *sqlRecordWriter {
+	w := &sqlRecordWriter{out: o, delim: []byte(defaultRecordDelimiter)}
+	if output.JSON != nil {
+		if output.JSON.RecordDelimiter != "" {
+			w.delim = []byte(output.JSON.RecordDelimiter)
+		}
+		if o.prefix {
+			// The object is added as the first field of each record.
+			name, _ := json.Marshal(url)
+			w.jsonPrefix = []byte(`{"_object":` + string(name))
+		}
+	} else if output.CSV != nil {
+		if output.CSV.RecordDelimiter != "" {
+			w.delim = []byte(output.CSV.RecordDelimiter)
+		}
+		quote, escape := csvQuotes(output.CSV)
+		w.quote, w.escape = []byte(quote), []byte(escape)
+		if o.prefix {
+			w.csvPrefix = []byte(csvField(url, output.CSV) + fieldDelimiter(output.CSV))
+		}
+	}
+	return w
+}
+
+func fieldDelimiter(opts *minio.CSVOutputOptions) string {
+	if opts.FieldDelimiter == "" {
+		return defaultFieldDelimiter
+	}
+	return opts.FieldDelimiter
+}
+
+// csvQuotes returns the characters quoting the fields of csv output
+// and escaping quotes within quoted fields.
+func csvQuotes(opts *minio.CSVOutputOptions) (quote, escape string) {
+	quote = opts.QuoteCharacter
+	if quote == "" {
+		quote = `"`
+	}
+	escape = opts.QuoteEscapeCharacter
+	if escape == "" {
+		escape = quote
+	}
+	return quote, escape
+}
+
+// csvField quotes a field of csv output when needed.
+func csvField(field string, opts *minio.CSVOutputOptions) string {
+	quote, escape := csvQuotes(opts)
+	if !strings.EqualFold(string(opts.QuoteFields), string(minio.CSVQuoteFieldsAlways)) &&
+		!strings.Contains(field, fieldDelimiter(opts)) && !strings.Contains(field, quote) &&
+		!strings.ContainsAny(field, "\r\n") {
+		return field
+	}
+	return quote + strings.Replace(field, quote, escape+quote, -1) + quote
+}
+
+// sqlRecordWriter buffers the select results of an object until they
+// form complete records. Record delimiters within quoted fields of csv
+// output are part of the field.
+type sqlRecordWriter struct {
+	out        *sqlOutput
+	delim      []byte
+	quote      []byte
+	escape     []byte
+	csvPrefix  []byte
+	jsonPrefix []byte
+	buf        []byte
+	// Length of buf scanned for the end of a record, and whether
+	// it ends within a quoted field.
+	scanned int
+	quoted  bool
+}
+
+func (w *sqlRecordWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	var b bytes.Buffer
+	start := 0
+	for end := w.recordEnd(); end >= 0; end = w.recordEnd() {
+		w.writeRecord(&b, w.buf[start:end])
+		start = end
+	}
+	if b.Len() > 0 {
+		if err := w.write(b.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	w.buf = append(w.buf[:0], w.buf[start:]...)
+	w.scanned -= start
+	return len(p), nil
+}
+
+// recordEnd returns the end of the next complete record in buf, or -1
+// if there is none yet.
+func (w *sqlRecordWriter) recordEnd() int {
+	i := w.scanned
+	for i < len(w.buf) {
+		rest := w.buf[i:]
+		// Wait for more data when the end of buf may be the start
+		// of a delimiter or a quote.
+		if isPartialToken(rest, w.delim) || isPartialToken(rest, w.quote) || isPartialToken(rest, w.escape) {
+			break
+		}
+		switch {
+		case w.quoted && !bytes.Equal(w.escape, w.quote) && len(w.escape) > 0 && bytes.HasPrefix(rest, w.escape):
+			if len(rest) == len(w.escape) {
+				w.scanned = i
+				return -1
+			}
+			// Skip the escaped character.
+			i += len(w.escape) + 1
+		case len(w.quote) > 0 && bytes.HasPrefix(rest, w.quote):
+			w.quoted = !w.quoted
+			i += len(w.quote)
+		case !w.quoted && bytes.HasPrefix(rest, w.delim):
+			w.scanned = i + len(w.delim)
+			return w.scanned
+		default:
+			i++
+		}
+	}
+	w.scanned = i
+	return -1
+}
+
+// isPartialToken returns true if data is a strict prefix of token.
+func isPartialToken(data, token []byte) bool {
+	return len(data) < len(token) && bytes.HasPrefix(token, data)
+}
+
+// Flush writes a last record which is not followed by a delimiter.
+func (w *sqlRecordWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	var b bytes.Buffer
+	w.writeRecord(&b, w.buf)
+	w.buf, w.scanned, w.quoted = nil, 0, false
+	return w.write(b.Bytes())
+}
+
+// writeRecord adds a record to b, tagged with its object if needed.
+func (w *sqlRecordWriter) writeRecord(b *bytes.Buffer, record []byte) {
+	switch {
+	case w.csvPrefix != nil:
+		b.Write(w.csvPrefix)
+		b.Write(record)
+	case w.jsonPrefix != nil && bytes.HasPrefix(record, []byte("{")):
+		b.Write(w.jsonPrefix)
+		if rest := bytes.TrimLeft(record[1:], " \t"); !bytes.HasPrefix(rest, []byte("}")) {
+			b.WriteByte(',')
+		}
+		b.Write(record[1:])
+	default:
+		b.Write(record)
+	}
+}
+
+// write writes complete records, records of parallel queries are
+// written one write at a time.
+func (w *sqlRecordWriter) write(records []byte) error {
+	w.out.mu.Lock()
+	defer w.out.mu.Unlock()
+	_, err := w.out.w.Write(records)
+	return err
+}
+
+// sqlStatsMessage container for the stats of a query on an object.
+type sqlStatsMessage struct {
+	Status         string `json:"status"`
+	URL            string `json:"url"`
+	BytesScanned   int64  `json:"bytesScanned"`
+	BytesProcessed int64  `json:"bytesProcessed"`
+	BytesReturned  int64  `json:"bytesReturned"`
+}
+
+// String stats of a query on an object.
+func (s sqlStatsMessage) String() string {
+	if s.Status == "error" {
+		return s.URL + ": failed"
+	}
+	return fmt.Sprintf("%s: %s scanned, %s processed, %s returned", s.URL,
+		humanize.IBytes(uint64(s.BytesScanned)), humanize.IBytes(uint64(s.BytesProcessed)),
+		humanize.IBytes(uint64(s.BytesReturned)))
+}
+
+// JSON jsonified stats of a query on an object.
+func (s sqlStatsMessage) JSON() string {
+	if s.Status == "" {
+		s.Status = "success"
+	}
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
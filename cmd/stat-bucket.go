@@ -0,0 +1,256 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// bucketEncryption is the default encryption of a bucket.
+type bucketEncryption struct {
+	Algorithm string `json:"algorithm"`
+	KMSKeyID  string `json:"kmsKeyId,omitempty"`
+}
+
+// bucketObjectLock is the object lock configuration of a bucket.
+type bucketObjectLock struct {
+	Enabled bool   `json:"enabled"`
+	Mode    string `json:"mode,omitempty"`
+	Days    int    `json:"days,omitempty"`
+	Years   int    `json:"years,omitempty"`
+}
+
+// bucketStatMessage container for the configuration of a bucket,
+// settings which cannot be read are listed in Errors.
+type bucketStatMessage struct {
+	Status        string               `json:"status"`
+	Key           string               `json:"name"`
+	Type          string               `json:"type"`
+	Region        string               `json:"region,omitempty"`
+	Policy        string               `json:"policy,omitempty"`
+	Versioning    string               `json:"versioning,omitempty"`
+	Encryption    *bucketEncryption    `json:"encryption,omitempty"`
+	ObjectLock    *bucketObjectLock    `json:"objectLock,omitempty"`
+	Lifecycle     []lifecycleRule      `json:"lifecycle,omitempty"`
+	Notifications []notificationConfig `json:"notifications,omitempty"`
+	CORS          []corsRule           `json:"cors,omitempty"`
+	Objects       *int64               `json:"objects,omitempty"`
+	Size          *int64               `json:"size,omitempty"`
+	Errors        map[string]string    `json:"errors,omitempty"`
+}
+
+// String colorized bucket configuration.
+func (b bucketStatMessage) String() string {
+	var lines []string
+	line := func(name, value string) {
+		lines = append(lines, fmt.Sprintf("%-10s: %s", name, value))
+	}
+	lines = append(lines, console.Colorize("Name", fmt.Sprintf("%-10s: %s", "Name", b.Key)))
+	line("Type", b.Type)
+	if b.Region != "" {
+		line("Region", b.Region)
+	}
+	if b.Policy != "" {
+		line("Policy", b.Policy)
+	}
+	if _, ok := b.Errors["versioning"]; !ok {
+		versioning := b.Versioning
+		if versioning == "" {
+			versioning = "Unversioned"
+		}
+		line("Versioning", versioning)
+	}
+	if b.Encryption != nil {
+		encryption := b.Encryption.Algorithm
+		if b.Encryption.KMSKeyID != "" {
+			encryption += " (" + b.Encryption.KMSKeyID + ")"
+		}
+		line("Encryption", encryption)
+	} else if _, ok := b.Errors["encryption"]; !ok {
+		line("Encryption", "none")
+	}
+	if b.ObjectLock != nil {
+		lock := "Enabled"
+		if b.ObjectLock.Mode != "" {
+			lock += ", " + b.ObjectLock.Mode
+			if b.ObjectLock.Days > 0 {
+				lock += fmt.Sprintf(" %d days", b.ObjectLock.Days)
+			}
+			if b.ObjectLock.Years > 0 {
+				lock += fmt.Sprintf(" %d years", b.ObjectLock.Years)
+			}
+		}
+		line("ObjectLock", lock)
+	} else if _, ok := b.Errors["objectLock"]; !ok {
+		line("ObjectLock", "none")
+	}
+	if _, ok := b.Errors["lifecycle"]; !ok {
+		line("Lifecycle", fmt.Sprintf("%d rules", len(b.Lifecycle)))
+		for _, rule := range b.Lifecycle {
+			var actions []string
+			if rule.Expiration != nil {
+				actions = append(actions, "expire after "+ilmDays(rule.Expiration.Days, rule.Expiration.Date))
+			}
+			for _, tr := range rule.Transitions {
+				actions = append(actions, "transition to "+tr.StorageClass+" after "+ilmDays(tr.Days, tr.Date))
+			}
+			lines = append(lines, fmt.Sprintf("  %s (%s) prefix '%s': %s", rule.ID, rule.Status,
+				rule.prefix(), strings.Join(actions, ", ")))
+		}
+	}
+	if _, ok := b.Errors["notifications"]; !ok {
+		line("Events", fmt.Sprintf("%d configured", len(b.Notifications)))
+		for _, config := range b.Notifications {
+			lines = append(lines, fmt.Sprintf("  %s %s prefix '%s' suffix '%s'", config.Arn,
+				strings.Join(config.Events, ","), config.Prefix, config.Suffix))
+		}
+	}
+	if _, ok := b.Errors["cors"]; !ok {
+		line("CORS", fmt.Sprintf("%d rules", len(b.CORS)))
+		for _, rule := range b.CORS {
+			lines = append(lines, fmt.Sprintf("  %s from %s", strings.Join(rule.AllowedMethods, ","),
+				strings.Join(rule.AllowedOrigins, ",")))
+		}
+	}
+	if b.Objects != nil {
+		line("Objects", fmt.Sprintf("%d", *b.Objects))
+	}
+	if b.Size != nil {
+		line("Size", humanize.IBytes(uint64(*b.Size)))
+	}
+	if len(b.Errors) > 0 {
+		var names []string
+		for name := range b.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		lines = append(lines, fmt.Sprintf("%-10s:", "Errors"))
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("  %s: %s", name, b.Errors[name]))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// JSON jsonified bucket configuration.
+func (b bucketStatMessage) JSON() string {
+	b.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// isBucketURL returns true if the URL is a bucket of an S3 server, a
+// trailing separator selects the contents of the bucket instead.
+func isBucketURL(targetURL string) bool {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return false
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return false
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	return bucket != "" && object == "" && !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator))
+}
+
+// statBucket reads the configuration of a bucket, the objects of the
+// bucket are counted if withUsage is set.
+func statBucket(targetURL string, withUsage bool) (bucketStatMessage, *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return bucketStatMessage{}, err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return bucketStatMessage{}, errInvalidArgument().Trace(targetURL)
+	}
+	if _, err = s3Clnt.Stat(false, false, nil); err != nil {
+		return bucketStatMessage{}, err.Trace(targetURL)
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+
+	msg := bucketStatMessage{Key: bucket, Type: "bucket", Errors: make(map[string]string)}
+	// Settings are read separately, servers may not support all of them.
+	fail := func(name string, err *probe.Error) {
+		msg.Errors[name] = err.ToGoError().Error()
+	}
+	if msg.Region, err = s3Clnt.GetRegion(); err != nil {
+		fail("region", err)
+	}
+	if msg.Policy, _, err = s3Clnt.GetAccess(); err != nil {
+		fail("policy", err)
+	}
+	if msg.Versioning, err = s3Clnt.GetVersioning(); err != nil {
+		fail("versioning", err)
+	}
+	if encryption, err := s3Clnt.GetEncryption(); err != nil {
+		fail("encryption", err)
+	} else if encryption != nil && len(encryption.Rules) > 0 {
+		msg.Encryption = &bucketEncryption{
+			Algorithm: encryption.Rules[0].Apply.SSEAlgorithm,
+			KMSKeyID:  encryption.Rules[0].Apply.KMSMasterKeyID,
+		}
+	}
+	if lock, err := s3Clnt.GetObjectLockConfig(); err != nil {
+		fail("objectLock", err)
+	} else if lock != nil && lock.ObjectLockEnabled == "Enabled" {
+		msg.ObjectLock = &bucketObjectLock{Enabled: true}
+		if lock.Rule != nil {
+			msg.ObjectLock.Mode = lock.Rule.DefaultRetention.Mode
+			msg.ObjectLock.Days = lock.Rule.DefaultRetention.Days
+			msg.ObjectLock.Years = lock.Rule.DefaultRetention.Years
+		}
+	}
+	if lifecycle, err := s3Clnt.GetLifecycle(); err != nil {
+		fail("lifecycle", err)
+	} else {
+		msg.Lifecycle = lifecycle.Rules
+	}
+	if msg.Notifications, err = s3Clnt.ListNotificationConfigs(""); err != nil {
+		fail("notifications", err)
+	}
+	if cors, err := s3Clnt.GetCORS(); err != nil {
+		fail("cors", err)
+	} else if cors != nil {
+		msg.CORS = cors.Rules
+	}
+
+	if withUsage {
+		var objects, size int64
+		for content := range clnt.List(true, false, DirNone) {
+			if content.Err != nil {
+				return bucketStatMessage{}, content.Err.Trace(targetURL)
+			}
+			if !content.Type.IsDir() {
+				objects++
+				size += content.Size
+			}
+		}
+		msg.Objects, msg.Size = &objects, &size
+	}
+	return msg, nil
+}
@@ -31,6 +31,14 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.StringFlag{
+			Name:  "version-id",
+			Usage: "stat a specific version of the object",
+		},
+		cli.BoolFlag{
+			Name:  "usage",
+			Usage: "count the objects of a bucket and their total size",
+		},
 	}
 )
 
@@ -69,6 +77,15 @@ EXAMPLES:
   5. Stat encrypted files on Amazon S3 cloud storage. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      $ {{.HelpName}} --encrypt-key "s3/personal-document/=MzJieXRlc2xvbmdzZWNyZWFiY2RlZmcJZ2l2ZW5uMjE=" s3/personal-document/2019-account_report.docx
+
+  6. Stat a specific version of an object on Amazon S3 cloud storage.
+     $ {{.HelpName}} --version-id "3a6c3e5f-24a1-4ed4-9c0f-a4f5e6f4e2b1" s3/mybucket/myobject.txt
+
+  7. Show the region, policy, versioning, encryption, object lock, lifecycle and notification settings of mybucket.
+     $ {{.HelpName}} s3/mybucket
+
+  8. Show the settings of mybucket with the number of objects and their total size.
+     $ {{.HelpName}} --usage s3/mybucket
 `,
 }
 
@@ -88,6 +105,14 @@ func checkStatSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	URLs := ctx.Args()
 	isIncomplete := false
 
+	// A version is stat'ed by mainStat, it may not be the latest one.
+	if ctx.String("version-id") != "" {
+		if len(URLs) != 1 || ctx.Bool("recursive") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "A version can only be stat'ed for a single object.")
+		}
+		return
+	}
+
 	for _, url := range URLs {
 		_, _, err := url2Stat(url, false, encKeyDB)
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
@@ -116,6 +141,8 @@ func mainStat(ctx *cli.Context) error {
 
 	// Set command flags from context.
 	isRecursive := ctx.Bool("recursive")
+	versionID := ctx.String("version-id")
+	withUsage := ctx.Bool("usage")
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -125,9 +152,22 @@ func mainStat(ctx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		stats, err := statURL(targetURL, false, isRecursive, encKeyDB)
-		if err != nil {
-			fatalIf(err, "Unable to stat `"+targetURL+"`.")
+		var stats []*clientContent
+		if versionID == "" && !isRecursive && isBucketURL(targetURL) {
+			msg, err := statBucket(targetURL, withUsage)
+			fatalIf(err, "Unable to stat bucket `"+targetURL+"`.")
+			printMsg(msg)
+			continue
+		}
+		if versionID != "" {
+			stat, err := statVersionURL(targetURL, versionID, encKeyDB)
+			fatalIf(err, "Unable to stat version `"+versionID+"` of `"+targetURL+"`.")
+			stats = append(stats, stat)
+		} else {
+			stats, err = statURL(targetURL, false, isRecursive, encKeyDB)
+			if err != nil {
+				fatalIf(err, "Unable to stat `"+targetURL+"`.")
+			}
 		}
 		for _, stat := range stats {
 			st := parseStat(stat)
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -35,10 +36,15 @@ type statMessage struct {
 	Date              time.Time         `json:"lastModified"`
 	Size              int64             `json:"size"`
 	ETag              string            `json:"etag"`
+	VersionID         string            `json:"versionId,omitempty"`
 	Type              string            `json:"type"`
 	Expires           time.Time         `json:"expires"`
 	EncryptionHeaders map[string]string `json:"encryption,omitempty"`
 	Metadata          map[string]string `json:"metadata"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	RetentionMode     string            `json:"retentionMode,omitempty"`
+	RetainUntilDate   *time.Time        `json:"retainUntilDate,omitempty"`
+	LegalHold         string            `json:"legalHold,omitempty"`
 }
 
 // String colorized string message.
@@ -51,10 +57,23 @@ func printStat(stat statMessage) {
 	if stat.ETag != "" {
 		console.Println(fmt.Sprintf("%-10s: %s ", "ETag", stat.ETag))
 	}
+	if stat.VersionID != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "VersionID", stat.VersionID))
+	}
 	console.Println(fmt.Sprintf("%-10s: %s ", "Type", stat.Type))
 	if !stat.Expires.IsZero() {
 		console.Println(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)))
 	}
+	if stat.RetentionMode != "" {
+		retention := stat.RetentionMode
+		if stat.RetainUntilDate != nil {
+			retention += " until " + stat.RetainUntilDate.Format(printDate)
+		}
+		console.Println(fmt.Sprintf("%-10s: %s ", "Retention", retention))
+	}
+	if stat.LegalHold != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "LegalHold", stat.LegalHold))
+	}
 	var maxKey = 0
 	for k := range stat.Metadata {
 		if len(k) > maxKey {
@@ -68,6 +87,21 @@ func printStat(stat statMessage) {
 		}
 	}
 	maxKey = 0
+	var tagKeys []string
+	for k := range stat.Tags {
+		tagKeys = append(tagKeys, k)
+		if len(k) > maxKey {
+			maxKey = len(k)
+		}
+	}
+	sort.Strings(tagKeys)
+	if len(stat.Tags) > 0 {
+		console.Println(fmt.Sprintf("%-10s:", "Tags"))
+		for _, k := range tagKeys {
+			console.Println(fmt.Sprintf("  %-*.*s: %s ", maxKey, maxKey, k, stat.Tags[k]))
+		}
+	}
+	maxKey = 0
 	for k := range stat.EncryptionHeaders {
 		if len(k) > maxKey {
 			maxKey = len(k)
@@ -107,11 +141,31 @@ func parseStat(c *clientContent) statMessage {
 	content.Metadata = c.Metadata
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
 	content.ETag = strings.TrimSuffix(content.ETag, "\"")
+	content.VersionID = c.VersionID
 	content.Expires = c.Expires
 	content.EncryptionHeaders = c.EncryptionHeaders
+	content.Tags = c.Tags
+	content.RetentionMode = c.RetentionMode
+	if !c.RetainUntilDate.IsZero() {
+		retainUntil := c.RetainUntilDate.Local()
+		content.RetainUntilDate = &retainUntil
+	}
+	content.LegalHold = c.LegalHold
 	return content
 }
 
+// statVersionURL - stat a specific version of an object.
+func statVersionURL(targetURL, versionID string, encKeyDB map[string][]prefixSSEPair) (*clientContent, *probe.Error) {
+	clnt, stat, err := url2StatVersion(targetURL, versionID, encKeyDB)
+	if err != nil {
+		return nil, err.Trace(targetURL, versionID)
+	}
+	// Trim the parent folder from the object path, like statURL.
+	separator := string(clnt.GetURL().Separator)
+	stat.URL.Path = stat.URL.Path[strings.LastIndex(stat.URL.Path, separator)+1:]
+	return stat, nil
+}
+
 // Return standardized URL to be used to compare later.
 func getStandardizedURL(targetURL string) string {
 	return filepath.FromSlash(targetURL)
@@ -165,9 +219,14 @@ func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[stri
 			return nil, errTargetNotFound(targetURL)
 		}
 
-		_, stat, err := url2Stat(url, true, encKeyDB)
+		statClnt, stat, err := url2Stat(url, true, encKeyDB)
 		if err != nil {
 			stat = content
+		} else if !stat.Type.IsDir() {
+			// Tags are only shown where supported.
+			if tags, tErr := statClnt.GetTags(); tErr == nil {
+				stat.Tags = tagsMap(tags)
+			}
 		}
 		// Convert any os specific delimiters to "/".
 		contentURL := filepath.ToSlash(stat.URL.Path)
@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	tagListFlags = []cli.Flag{}
+)
+
+var tagListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list tags of an object or a bucket",
+	Action: mainTagList,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagListFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List tags of an object.
+     $ {{.HelpName}} s3/mybucket/myobject.txt
+
+  2. List tags of a bucket in JSON format.
+     $ {{.HelpName}} --json s3/mybucket
+`,
+}
+
+// checkTagListSyntax - validate all the passed arguments
+func checkTagListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+func mainTagList(ctx *cli.Context) error {
+	console.SetColor("Tag", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Key", color.New(color.FgCyan, color.Bold))
+
+	checkTagListSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	tags, err := client.GetTags()
+	fatalIf(err.Trace(urlStr), "Unable to get tags of `"+urlStr+"`.")
+
+	printMsg(tagMessage{op: "list", Target: urlStr, Tags: tagsMap(tags)})
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	tagFlags = []cli.Flag{}
+)
+
+var tagCmd = cli.Command{
+	Name:            "tag",
+	Usage:           "manage tags of objects and buckets",
+	HideHelpCommand: true,
+	Action:          mainTag,
+	Before:          setGlobalsFromContext,
+	Flags:           append(tagFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		tagSetCmd,
+		tagListCmd,
+		tagRemoveCmd,
+	},
+}
+
+// mainTag is the handle for "mc tag" command.
+func mainTag(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "list", "remove" have their own main.
+}
+
+// tagMessage container for tag messages.
+type tagMessage struct {
+	op     string
+	Status string            `json:"status"`
+	Target string            `json:"target"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+func (t tagMessage) String() string {
+	switch t.op {
+	case "list":
+		if len(t.Tags) == 0 {
+			return console.Colorize("Tag", fmt.Sprintf("No tags found on `%s`.", t.Target))
+		}
+		var keys []string
+		maxKey := 0
+		for k := range t.Tags {
+			keys = append(keys, k)
+			if len(k) > maxKey {
+				maxKey = len(k)
+			}
+		}
+		sort.Strings(keys)
+		var lines []string
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s : %s", console.Colorize("Key", fmt.Sprintf("%-*s", maxKey, k)), t.Tags[k]))
+		}
+		return strings.Join(lines, "\n")
+	case "set":
+		return console.Colorize("Tag", fmt.Sprintf("Tags set on `%s`.", t.Target))
+	case "remove":
+		return console.Colorize("Tag", fmt.Sprintf("Tags removed from `%s`.", t.Target))
+	}
+	return ""
+}
+
+func (t tagMessage) JSON() string {
+	t.Status = "success"
+	tagMessageJSONBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(tagMessageJSONBytes)
+}
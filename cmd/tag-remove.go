@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	tagRemoveFlags = []cli.Flag{}
+)
+
+var tagRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove all tags of an object or a bucket",
+	Action: mainTagRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove tags of an object.
+     $ {{.HelpName}} s3/mybucket/myobject.txt
+
+  2. Remove tags of a bucket.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// checkTagRemoveSyntax - validate all the passed arguments
+func checkTagRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+func mainTagRemove(ctx *cli.Context) error {
+	console.SetColor("Tag", color.New(color.FgGreen, color.Bold))
+
+	checkTagRemoveSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	fatalIf(client.SetTags(nil).Trace(urlStr), "Unable to remove tags of `"+urlStr+"`.")
+
+	printMsg(tagMessage{op: "remove", Target: urlStr})
+	return nil
+}
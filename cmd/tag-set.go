@@ -0,0 +1,76 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	tagSetFlags = []cli.Flag{}
+)
+
+var tagSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set tags of an object or a bucket, existing tags are replaced",
+	Action: mainTagSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET TAGS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Set tags of an object.
+     $ {{.HelpName}} s3/mybucket/myobject.txt "project=alpha&owner=ops"
+
+  2. Set tags of a bucket.
+     $ {{.HelpName}} s3/mybucket "cost-center=research"
+`,
+}
+
+// checkTagSetSyntax - validate all the passed arguments
+func checkTagSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 || ctx.Args().Get(1) == "" {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+func mainTagSet(ctx *cli.Context) error {
+	console.SetColor("Tag", color.New(color.FgGreen, color.Bold))
+
+	checkTagSetSyntax(ctx)
+
+	urlStr, tagsStr := ctx.Args().Get(0), ctx.Args().Get(1)
+	tags, err := parseTags(tagsStr)
+	fatalIf(err, "Invalid tags.")
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	fatalIf(client.SetTags(tags).Trace(urlStr), "Unable to set tags of `"+urlStr+"`.")
+
+	printMsg(tagMessage{op: "set", Target: urlStr, Tags: tagsMap(tags)})
+	return nil
+}
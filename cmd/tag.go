@@ -0,0 +1,125 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Limits of the tags of objects and buckets.
+const (
+	maxObjectTags     = 10
+	maxBucketTags     = 50
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// tagsMetadataKey is the metadata key holding the tags of an uploaded
+// object, in the form "key1=value1&key2=value2".
+const tagsMetadataKey = "X-Amz-Tagging"
+
+// objectTag is a tag of an object or a bucket.
+type objectTag struct {
+	Key   string `xml:"Key" json:"key"`
+	Value string `xml:"Value" json:"value"`
+}
+
+// tagging is the tag set of an object or a bucket in the S3 API.
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  struct {
+		Tags []objectTag `xml:"Tag"`
+	} `xml:"TagSet"`
+}
+
+// parseTags parses tags of the form "key1=value1&key2=value2", they
+// are sorted by key.
+func parseTags(tagsStr string) ([]objectTag, *probe.Error) {
+	if tagsStr == "" {
+		return nil, nil
+	}
+	values, e := url.ParseQuery(tagsStr)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(tagsStr)
+	}
+	var tags []objectTag
+	for key, value := range values {
+		if key == "" || len(value) != 1 {
+			return nil, probe.NewError(fmt.Errorf("invalid tag `%s`, tags must be of the form key1=value1&key2=value2", key)).Trace(tagsStr)
+		}
+		tags = append(tags, objectTag{Key: key, Value: value[0]})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// validateTags verifies that tags can be set on an object, or on a
+// bucket if isBucket is set.
+func validateTags(tags []objectTag, isBucket bool) *probe.Error {
+	maxTags := maxObjectTags
+	if isBucket {
+		maxTags = maxBucketTags
+	}
+	if len(tags) > maxTags {
+		return probe.NewError(fmt.Errorf("too many tags, at most %d tags can be set", maxTags))
+	}
+	for _, tag := range tags {
+		if len(tag.Key) > maxTagKeyLength {
+			return probe.NewError(fmt.Errorf("tag key `%s` is longer than %d characters", tag.Key, maxTagKeyLength))
+		}
+		if len(tag.Value) > maxTagValueLength {
+			return probe.NewError(fmt.Errorf("value of tag `%s` is longer than %d characters", tag.Key, maxTagValueLength))
+		}
+	}
+	return nil
+}
+
+// checkTagsFlag verifies the tags given to --tags of an upload.
+func checkTagsFlag(tagsStr string) *probe.Error {
+	tags, err := parseTags(tagsStr)
+	if err != nil {
+		return err.Trace(tagsStr)
+	}
+	return validateTags(tags, false).Trace(tagsStr)
+}
+
+// tagsString formats tags as "key1=value1&key2=value2".
+func tagsString(tags []objectTag) string {
+	var tagStrs []string
+	for _, tag := range tags {
+		tagStrs = append(tagStrs, tag.Key+"="+tag.Value)
+	}
+	return strings.Join(tagStrs, "&")
+}
+
+// tagsMap returns tags as a map of keys to values.
+func tagsMap(tags []objectTag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[tag.Key] = tag.Value
+	}
+	return m
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		tagsStr string
+		tags    []objectTag
+		success bool
+	}{
+		{"", nil, true},
+		{"k=v", []objectTag{{"k", "v"}}, true},
+		{"k2=v2&k1=v1", []objectTag{{"k1", "v1"}, {"k2", "v2"}}, true},
+		{"k=", []objectTag{{"k", ""}}, true},
+		{"a%20b=c%26d", []objectTag{{"a b", "c&d"}}, true},
+		{"k=v1&k=v2", nil, false},
+		{"=v", nil, false},
+		{"k=%zz", nil, false},
+	}
+	for i, testCase := range testCases {
+		tags, err := parseTags(testCase.tagsStr)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && !reflect.DeepEqual(tags, testCase.tags) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.tags, tags)
+		}
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	var tags []objectTag
+	for i := 0; i < maxObjectTags; i++ {
+		tags = append(tags, objectTag{Key: "k" + strconv.Itoa(i)})
+	}
+	if err := validateTags(tags, false); err != nil {
+		t.Errorf("expected %d object tags to be valid, got %s", maxObjectTags, err)
+	}
+	tags = append(tags, objectTag{Key: "extra"})
+	if err := validateTags(tags, false); err == nil {
+		t.Errorf("expected %d object tags to be invalid", len(tags))
+	}
+	if err := validateTags(tags, true); err != nil {
+		t.Errorf("expected %d bucket tags to be valid, got %s", len(tags), err)
+	}
+	if err := validateTags([]objectTag{{Key: strings.Repeat("k", maxTagKeyLength+1)}}, false); err == nil {
+		t.Errorf("expected a long key to be invalid")
+	}
+	if err := validateTags([]objectTag{{Key: "k", Value: strings.Repeat("v", maxTagValueLength+1)}}, false); err == nil {
+		t.Errorf("expected a long value to be invalid")
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Status of an object in a transfer report.
+const (
+	reportCopied  = "copied"
+	reportMoved   = "moved"
+	reportSkipped = "skipped"
+	reportRemoved = "removed"
+	reportRotated = "rotated"
+	reportFailed  = "failed"
+)
+
+// transferReportEntry is the outcome of a single object.
+type transferReportEntry struct {
+	Status   string  `json:"status"`
+	Source   string  `json:"source,omitempty"`
+	Target   string  `json:"target,omitempty"`
+	Size     int64   `json:"size"`
+	Duration float64 `json:"duration"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// transferReport writes an entry per object to a file, as a JSON
+// object per line or as CSV records. It is safe for concurrent use,
+// and a nil report discards all entries.
+type transferReport struct {
+	mutex sync.Mutex
+	file  *os.File
+	csv   *csv.Writer
+}
+
+// Header of transfer reports in CSV format.
+var transferReportCSVHeader = []string{"status", "source", "target", "size", "duration", "error"}
+
+// newTransferReport opens the report file, a file name ending with
+// '.csv' selects the CSV format. An existing report is truncated
+// unless isAppend is set, which is used when resuming sessions.
+func newTransferReport(reportPath string, isAppend bool) (*transferReport, *probe.Error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if isAppend {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, e := os.OpenFile(reportPath, flags, 0644)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(reportPath)
+	}
+	r := &transferReport{file: file}
+	if strings.EqualFold(filepath.Ext(reportPath), ".csv") {
+		r.csv = csv.NewWriter(file)
+		// Write the header only once.
+		if offset, e := file.Seek(0, io.SeekEnd); e == nil && offset == 0 {
+			r.csv.Write(transferReportCSVHeader)
+			r.csv.Flush()
+		}
+	}
+	return r, nil
+}
+
+// add writes an entry to the report.
+func (r *transferReport) add(entry transferReportEntry) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.csv != nil {
+		r.csv.Write([]string{
+			entry.Status,
+			entry.Source,
+			entry.Target,
+			strconv.FormatInt(entry.Size, 10),
+			strconv.FormatFloat(entry.Duration, 'f', 6, 64),
+			entry.Error,
+		})
+		r.csv.Flush()
+		return
+	}
+	entryBytes, e := json.Marshal(entry)
+	if e != nil {
+		return
+	}
+	r.file.Write(append(entryBytes, '\n'))
+}
+
+// addURLs writes the outcome of a transfer of urls, which is a failure
+// if urls has an error.
+func (r *transferReport) addURLs(status string, urls URLs, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	entry := transferReportEntry{
+		Status:   status,
+		Duration: duration.Seconds(),
+	}
+	if urls.SourceContent != nil {
+		entry.Source = filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+		entry.Size = urls.SourceContent.Size
+	}
+	if urls.TargetContent != nil {
+		entry.Target = filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+		if urls.SourceContent == nil {
+			entry.Size = urls.TargetContent.Size
+		}
+	}
+	if urls.Error != nil {
+		entry.Status = reportFailed
+		entry.Error = urls.Error.ToGoError().Error()
+	}
+	r.add(entry)
+}
+
+// Close closes the report file.
+func (r *transferReport) Close() *probe.Error {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if e := r.file.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// openTransferReport sets the global transfer report, an empty path
+// means no report.
+func openTransferReport(reportPath string, isAppend bool) *probe.Error {
+	if reportPath == "" {
+		return nil
+	}
+	report, err := newTransferReport(reportPath, isAppend)
+	if err != nil {
+		return err.Trace(reportPath)
+	}
+	globalTransferReport = report
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestTransferReport(t *testing.T) {
+	dir, e := ioutil.TempDir(os.TempDir(), "mc-report-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	urls := URLs{
+		SourceAlias:   "play",
+		SourceContent: &clientContent{URL: *newClientURL("/bucket/object"), Size: 42},
+		TargetContent: &clientContent{URL: *newClientURL("/tmp/object")},
+	}
+
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"report.json", `{"status":"copied","source":"play/bucket/object","target":"/tmp/object","size":42,"duration":1.5}
+{"status":"failed","source":"play/bucket/object","target":"/tmp/object","size":42,"duration":0,"error":"disk full"}
+`},
+		{"report.csv", `status,source,target,size,duration,error
+copied,play/bucket/object,/tmp/object,42,1.500000,
+failed,play/bucket/object,/tmp/object,42,0.000000,disk full
+`},
+	}
+	for i, testCase := range testCases {
+		reportPath := filepath.Join(dir, testCase.name)
+		// The second entry is appended as for a resumed session.
+		for j, isAppend := range []bool{false, true} {
+			report, err := newTransferReport(reportPath, isAppend)
+			if err != nil {
+				t.Fatalf("Test %d: unable to create report: %v", i+1, err)
+			}
+			if j == 0 {
+				report.addURLs(reportCopied, urls, 1500*time.Millisecond)
+			} else {
+				report.addURLs(reportCopied, urls.WithError(probe.NewError(errors.New("disk full"))), 0)
+			}
+			if err = report.Close(); err != nil {
+				t.Fatalf("Test %d: unable to close report: %v", i+1, err)
+			}
+		}
+		data, e := ioutil.ReadFile(reportPath)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if got := strings.Replace(string(data), "\r\n", "\n", -1); got != testCase.expected {
+			t.Errorf("Test %d: expected report\n%s\ngot\n%s", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -43,7 +43,7 @@ func isErrIgnored(err *probe.Error) (ignored bool) {
 	case BrokenSymlink, TooManyLevelsSymlink, PathNotFound:
 		ignored = true
 	// Handle these specifically for object storage related errors.
-	case BucketNameEmpty, ObjectMissing, ObjectAlreadyExists:
+	case BucketNameEmpty, ObjectMissing, ObjectAlreadyExists, SameFile:
 		ignored = true
 	case ObjectAlreadyExistsAsDirectory, BucketDoesNotExist, BucketInvalid, ObjectOnGlacier:
 		ignored = true
@@ -213,13 +213,21 @@ func parseAndValidateEncryptionKeys(sseKeys string, sse string) (encMap map[stri
 		return nil, err
 	}
 	if sse != "" {
-		for _, prefix := range strings.Split(sse, ",") {
+		for _, prefixSSE := range strings.Split(sse, ",") {
+			prefix, serverSSE, err := parseServerEncryption(prefixSSE)
+			if err != nil {
+				return nil, err
+			}
 			alias, _ := url2Alias(prefix)
 			encMap[alias] = append(encMap[alias], prefixSSEPair{
 				Prefix: prefix,
-				SSE:    encrypt.NewSSE(),
+				SSE:    serverSSE,
 			})
 		}
+		// The longest matching prefix selects the encryption of an object.
+		for _, encKeys := range encMap {
+			sort.Sort(byPrefixLength(encKeys))
+		}
 	}
 	for alias, ps := range encMap {
 		if hostCfg := mustGetHostConfig(alias); hostCfg == nil {
@@ -231,6 +239,44 @@ func parseAndValidateEncryptionKeys(sseKeys string, sse string) (encMap map[stri
 	return encMap, nil
 }
 
+// sseKMSPrefix prefixes the key ID of SSE-KMS in --encrypt values.
+const sseKMSPrefix = "kms:"
+
+// parseServerEncryption parses a prefix and its server-side encryption
+// with server managed keys, given as "prefix" for SSE-S3 or as
+// "prefix=kms:key-id" for SSE-KMS. An encryption context can be passed
+// to KMS as "prefix=kms:key-id?key1=value1&key2=value2".
+func parseServerEncryption(prefixSSE string) (string, encrypt.ServerSide, *probe.Error) {
+	i := strings.Index(prefixSSE, "=")
+	if i == -1 {
+		return prefixSSE, encrypt.NewSSE(), nil
+	}
+	prefix, value := prefixSSE[:i], prefixSSE[i+1:]
+	if prefix == "" || !strings.HasPrefix(value, sseKMSPrefix) {
+		return "", nil, probe.NewError(errors.New("SSE prefix should be of the form prefix or prefix=kms:key-id, got " + prefixSSE))
+	}
+	keyID := strings.TrimPrefix(value, sseKMSPrefix)
+	var context interface{}
+	if j := strings.Index(keyID, "?"); j != -1 {
+		tags, err := parseTags(keyID[j+1:])
+		if err != nil {
+			return "", nil, probe.NewError(errors.New("SSE-KMS context should be of the form key1=value1&key2=value2, got " + keyID[j+1:]))
+		}
+		if len(tags) > 0 {
+			context = tagsMap(tags)
+		}
+		keyID = keyID[:j]
+	}
+	if keyID == "" {
+		return "", nil, probe.NewError(errors.New("SSE-KMS key ID cannot be empty for prefix " + prefix))
+	}
+	sse, e := encrypt.NewSSEKMS(keyID, context)
+	if e != nil {
+		return "", nil, probe.NewError(e)
+	}
+	return prefix, sse, nil
+}
+
 // parse list of comma separated alias/prefix=sse key values entered on command line and
 // construct a map of alias to prefix and sse pairs.
 func parseEncryptionKeys(sseKeys string) (encMap map[string][]prefixSSEPair, err *probe.Error) {
@@ -159,3 +159,45 @@ func TestParseEncryptionKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestParseServerEncryption(t *testing.T) {
+	sseKMS, err := encrypt.NewSSEKMS("my-key-id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sseKMSContext, err := encrypt.NewSSEKMS("arn:aws:kms:us-east-1:123456789012:key/my-key-id", map[string]string{"department": "finance", "project": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		prefixSSE      string
+		expectedPrefix string
+		expectedSSE    encrypt.ServerSide
+		success        bool
+	}{
+		{"myminio/bucket", "myminio/bucket", encrypt.NewSSE(), true},
+		{"myminio/bucket/secret=kms:my-key-id", "myminio/bucket/secret", sseKMS, true},
+		{"myminio/bucket=kms:arn:aws:kms:us-east-1:123456789012:key/my-key-id?project=x&department=finance", "myminio/bucket", sseKMSContext, true},
+		{"myminio/bucket=kms:my-key-id?", "myminio/bucket", sseKMS, true},
+		{"myminio/bucket=kms:", "", nil, false},
+		{"myminio/bucket=kms:?department=finance", "", nil, false},
+		{"myminio/bucket=my-key-id", "", nil, false},
+		{"=kms:my-key-id", "", nil, false},
+		{"myminio/bucket=kms:my-key-id?=finance", "", nil, false},
+	}
+	for i, testCase := range testCases {
+		prefix, sse, err := parseServerEncryption(testCase.prefixSSE)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: Expected success, got %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: Expected error, got success", i+1)
+		}
+		if prefix != testCase.expectedPrefix {
+			t.Errorf("Test %d: Expected prefix %s, got %s", i+1, testCase.expectedPrefix, prefix)
+		}
+		if !reflect.DeepEqual(sse, testCase.expectedSSE) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expectedSSE, sse)
+		}
+	}
+}
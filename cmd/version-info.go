@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var versionInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show bucket versioning state",
+	Action: mainVersionInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the versioning state of mybucket on Amazon S3 cloud storage.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// mainVersionInfo is the handle for "mc version info" command.
+func mainVersionInfo(ctx *cli.Context) error {
+	console.SetColor("Versioning", color.New(color.FgGreen, color.Bold))
+	checkVersioningSyntax(ctx, "info")
+
+	targetURL := ctx.Args().First()
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	status, err := clnt.GetVersioning()
+	fatalIf(err.Trace(targetURL), "Unable to get versioning of `"+targetURL+"`.")
+
+	printMsg(versioningMessage{op: "info", URL: targetURL, Versioning: status})
+	return nil
+}
@@ -26,12 +26,13 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
-// Print version.
+// Print version, sub-commands manage the versioning of buckets.
 var versionCmd = cli.Command{
-	Name:   "version",
-	Usage:  "show version info",
-	Action: mainVersion,
-	Before: setGlobalsFromContext,
+	Name:            "version",
+	Usage:           "show version info, manage bucket versioning",
+	Action:          mainVersion,
+	Before:          setGlobalsFromContext,
+	HideHelpCommand: true,
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "quiet, q",
@@ -42,21 +43,89 @@ var versionCmd = cli.Command{
 			Usage: "enable JSON formatted output",
 		},
 	},
+	Subcommands: []cli.Command{
+		versionEnableCmd,
+		versionSuspendCmd,
+		versionInfoCmd,
+	},
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}}{{if .VisibleFlags}} [FLAGS]{{end}}
-{{if .VisibleFlags}}
+  {{.HelpName}}{{if .VisibleFlags}} [FLAGS]{{end}} [COMMAND TARGET]
+
+COMMANDS:
+  {{range .VisibleCommands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+  {{end}}{{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
 EXAMPLES:
   1. Prints the MinIO Client version:
      $ {{.HelpName}}
+
+  2. Enable versioning of mybucket on Amazon S3 cloud storage.
+     $ {{.HelpName}} enable s3/mybucket
+
+  3. Show the versioning state of mybucket on Amazon S3 cloud storage.
+     $ {{.HelpName}} info s3/mybucket
 `,
 }
 
+// versioningMessage container for the versioning state of a bucket.
+type versioningMessage struct {
+	op         string
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+	Versioning string `json:"versioning"`
+}
+
+// Colorized message for console printing.
+func (v versioningMessage) String() string {
+	switch v.op {
+	case "enable":
+		return console.Colorize("Versioning", "Versioning is enabled for `"+v.URL+"`.")
+	case "suspend":
+		return console.Colorize("Versioning", "Versioning is suspended for `"+v.URL+"`.")
+	}
+	versioning := v.Versioning
+	if versioning == "" {
+		versioning = "Unversioned"
+	}
+	return console.Colorize("Versioning", "`"+v.URL+"` versioning: "+versioning)
+}
+
+// JSON'ified message for scripting.
+func (v versioningMessage) JSON() string {
+	v.Status = "success"
+	msgBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkVersioningSyntax - validate the arguments of the versioning
+// sub-commands.
+func checkVersioningSyntax(ctx *cli.Context, name string) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, name, 1) // last argument is exit code
+	}
+}
+
+// setVersioning enables or suspends the versioning of the bucket of
+// the sub-command.
+func setVersioning(ctx *cli.Context, op, status string) error {
+	console.SetColor("Versioning", color.New(color.FgGreen, color.Bold))
+	checkVersioningSyntax(ctx, op)
+
+	targetURL := ctx.Args().First()
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	fatalIf(clnt.SetVersioning(status).Trace(targetURL), "Unable to "+op+" versioning of `"+targetURL+"`.")
+
+	printMsg(versioningMessage{op: op, URL: targetURL, Versioning: status})
+	return nil
+}
+
 // Structured message depending on the type of console.
 type versionMessage struct {
 	Status  string `json:"status"`
@@ -84,6 +153,10 @@ func (v versionMessage) JSON() string {
 }
 
 func mainVersion(ctx *cli.Context) error {
+	// Unknown sub-commands are not mistaken for a version request.
+	if ctx.Args().Present() {
+		cli.ShowAppHelpAndExit(ctx, 1)
+	}
 
 	// Additional command speific theme customization.
 	console.SetColor("Version", color.New(color.FgGreen, color.Bold))
@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "github.com/minio/cli"
+
+var versionSuspendCmd = cli.Command{
+	Name:   "suspend",
+	Usage:  "suspend bucket versioning",
+	Action: mainVersionSuspend,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Suspend versioning of mybucket on Amazon S3 cloud storage, existing versions are kept.
+     $ {{.HelpName}} s3/mybucket
+`,
+}
+
+// mainVersionSuspend is the handle for "mc version suspend" command.
+func mainVersionSuspend(ctx *cli.Context) error {
+	return setVersioning(ctx, "suspend", versioningSuspended)
+}
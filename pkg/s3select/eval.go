@@ -0,0 +1,702 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// value is the result of an expression, one of nil, bool, int64,
+// float64, string, []value or *object.
+type value interface{}
+
+// object is a record or a nested JSON object, keys keep their input order.
+type object struct {
+	keys   []string
+	values []value
+}
+
+func (o *object) add(key string, v value) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, v)
+}
+
+// get looks up a field, unquoted names fall back to a case
+// insensitive match.
+func (o *object) get(name string, quoted bool) (value, bool) {
+	for i, k := range o.keys {
+		if k == name {
+			return o.values[i], true
+		}
+	}
+	if !quoted {
+		for i, k := range o.keys {
+			if strings.EqualFold(k, name) {
+				return o.values[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+var scalarFuncs = map[string]bool{
+	"lower": true, "upper": true, "char_length": true, "character_length": true,
+	"coalesce": true, "nullif": true,
+}
+
+func checkArity(call *callExpr) error {
+	n := len(call.args)
+	var ok bool
+	switch call.name {
+	case "coalesce":
+		ok = n >= 1
+	case "nullif":
+		ok = n == 2
+	default:
+		ok = n == 1
+	}
+	if !ok {
+		return fmt.Errorf("wrong number of arguments to %s", strings.ToUpper(call.name))
+	}
+	return nil
+}
+
+// aggregate is the running state of an aggregate function.
+type aggregate struct {
+	count int64
+	sum   value
+	min   value
+	max   value
+}
+
+func (a *aggregate) update(call *callExpr, rec *object, q *Query) error {
+	if call.star {
+		a.count++
+		return nil
+	}
+	v, err := q.eval(call.args[0], rec)
+	if err != nil || v == nil {
+		return err
+	}
+	a.count++
+	switch call.name {
+	case "sum", "avg":
+		n, ok := toNumber(v)
+		if !ok {
+			return fmt.Errorf("%s: %v is not a number", strings.ToUpper(call.name), v)
+		}
+		if a.sum == nil {
+			a.sum = n
+		} else if a.sum, err = arithmetic("+", a.sum, n); err != nil {
+			return err
+		}
+	case "min", "max":
+		for _, m := range []*value{&a.min, &a.max} {
+			if *m == nil {
+				*m = v
+				continue
+			}
+			// CSV fields are strings, they are compared as numbers
+			// when both parse like SUM does.
+			l, r := v, *m
+			if ln, lok := toNumber(l); lok {
+				if rn, rok := toNumber(r); rok {
+					l, r = ln, rn
+				}
+			}
+			c, ok := compare(l, r)
+			if ok && ((m == &a.min && c < 0) || (m == &a.max && c > 0)) {
+				*m = v
+			}
+		}
+	}
+	return nil
+}
+
+func (a *aggregate) result(name string) value {
+	switch name {
+	case "count":
+		return a.count
+	case "sum":
+		return a.sum
+	case "avg":
+		if a.count == 0 {
+			return nil
+		}
+		f, _ := toFloat(a.sum)
+		return f / float64(a.count)
+	case "min":
+		return a.min
+	case "max":
+		return a.max
+	}
+	return nil
+}
+
+// eval evaluates an expression against a record.
+func (q *Query) eval(e expr, rec *object) (value, error) {
+	switch e := e.(type) {
+	case *literalExpr:
+		return e.v, nil
+	case *columnExpr:
+		return q.lookup(e, rec), nil
+	case *unaryExpr:
+		x, err := q.eval(e.x, rec)
+		if err != nil || x == nil {
+			return nil, err
+		}
+		if e.op == "not" {
+			b, ok := x.(bool)
+			if !ok {
+				return nil, fmt.Errorf("NOT: %v is not a boolean", x)
+			}
+			return !b, nil
+		}
+		return arithmetic("*", int64(-1), x)
+	case *binaryExpr:
+		return q.evalBinary(e, rec)
+	case *isNullExpr:
+		x, err := q.eval(e.x, rec)
+		if err != nil {
+			return nil, err
+		}
+		return (x == nil) != e.not, nil
+	case *likeExpr:
+		return q.evalLike(e, rec)
+	case *betweenExpr:
+		x, err := q.eval(e.x, rec)
+		if err != nil {
+			return nil, err
+		}
+		lo, err := q.eval(e.lo, rec)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := q.eval(e.hi, rec)
+		if err != nil {
+			return nil, err
+		}
+		c1, ok1 := compare(x, lo)
+		c2, ok2 := compare(x, hi)
+		if !ok1 || !ok2 {
+			return nil, nil
+		}
+		return (c1 >= 0 && c2 <= 0) != e.not, nil
+	case *inExpr:
+		x, err := q.eval(e.x, rec)
+		if err != nil || x == nil {
+			return nil, err
+		}
+		for _, item := range e.list {
+			v, err := q.eval(item, rec)
+			if err != nil {
+				return nil, err
+			}
+			if c, ok := compare(x, v); ok && c == 0 {
+				return !e.not, nil
+			}
+		}
+		return e.not, nil
+	case *castExpr:
+		x, err := q.eval(e.x, rec)
+		if err != nil {
+			return nil, err
+		}
+		return cast(x, e.typ)
+	case *trimExpr:
+		return q.evalTrim(e, rec)
+	case *substringExpr:
+		return q.evalSubstring(e, rec)
+	case *callExpr:
+		if e.agg != nil {
+			return e.agg.result(e.name), nil
+		}
+		return q.evalCall(e, rec)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", e)
+}
+
+// lookup resolves a column path, missing fields evaluate to null.
+func (q *Query) lookup(col *columnExpr, rec *object) value {
+	path := col.path
+	if len(path) > 1 && !path[0].quoted &&
+		(strings.EqualFold(path[0].name, "s3object") ||
+			(q.tableAlias != "" && strings.EqualFold(path[0].name, q.tableAlias))) {
+		path = path[1:]
+	}
+	var cur value = rec
+	for i, elem := range path {
+		switch v := cur.(type) {
+		case *object:
+			if elem.isIndex {
+				return nil
+			}
+			next, ok := v.get(elem.name, elem.quoted)
+			if !ok && i == 0 {
+				// _N refers to the N-th column of the record.
+				if n, err := strconv.Atoi(strings.TrimPrefix(elem.name, "_")); err == nil &&
+					strings.HasPrefix(elem.name, "_") && n >= 1 && n <= len(v.values) {
+					next, ok = v.values[n-1], true
+				}
+			}
+			if !ok {
+				return nil
+			}
+			cur = next
+		case []value:
+			if !elem.isIndex || elem.index >= len(v) {
+				return nil
+			}
+			cur = v[elem.index]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+func (q *Query) evalBinary(e *binaryExpr, rec *object) (value, error) {
+	l, err := q.eval(e.l, rec)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "and" || e.op == "or" {
+		lb, lok := l.(bool)
+		if l != nil && !lok {
+			return nil, fmt.Errorf("%s: %v is not a boolean", strings.ToUpper(e.op), l)
+		}
+		// Short circuit when the left side decides the result.
+		if lok && lb == (e.op == "or") {
+			return lb, nil
+		}
+		r, err := q.eval(e.r, rec)
+		if err != nil {
+			return nil, err
+		}
+		rb, rok := r.(bool)
+		if r != nil && !rok {
+			return nil, fmt.Errorf("%s: %v is not a boolean", strings.ToUpper(e.op), r)
+		}
+		if rok && rb == (e.op == "or") {
+			return rb, nil
+		}
+		if l == nil || r == nil {
+			return nil, nil
+		}
+		return rb, nil
+	}
+
+	r, err := q.eval(e.r, rec)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	switch e.op {
+	case "||":
+		return toString(l) + toString(r), nil
+	case "+", "-", "*", "/", "%":
+		return arithmetic(e.op, l, r)
+	}
+	c, ok := compare(l, r)
+	if !ok {
+		return nil, nil
+	}
+	switch e.op {
+	case "=":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return nil, fmt.Errorf("unsupported operator %s", e.op)
+}
+
+func (q *Query) evalLike(e *likeExpr, rec *object) (value, error) {
+	x, err := q.eval(e.x, rec)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := q.eval(e.pattern, rec)
+	if err != nil {
+		return nil, err
+	}
+	escape := `\`
+	if e.escape != nil {
+		v, err := q.eval(e.escape, rec)
+		if err != nil {
+			return nil, err
+		}
+		if escape = toString(v); len([]rune(escape)) != 1 {
+			return nil, errors.New("LIKE: escape must be a single character")
+		}
+	}
+	if x == nil || pattern == nil {
+		return nil, nil
+	}
+	re, err := likeRegexp(toString(pattern), []rune(escape)[0])
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(toString(x)) != e.not, nil
+}
+
+// likeRegexp translates a LIKE pattern into a regular expression.
+func likeRegexp(pattern string, escape rune) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		return nil, errors.New("LIKE: pattern ends with the escape character")
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+func (q *Query) evalTrim(e *trimExpr, rec *object) (value, error) {
+	x, err := q.eval(e.x, rec)
+	if err != nil || x == nil {
+		return nil, err
+	}
+	chars := " "
+	if e.chars != nil {
+		v, err := q.eval(e.chars, rec)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		chars = toString(v)
+	}
+	s := toString(x)
+	switch e.where {
+	case "leading":
+		return strings.TrimLeft(s, chars), nil
+	case "trailing":
+		return strings.TrimRight(s, chars), nil
+	}
+	return strings.Trim(s, chars), nil
+}
+
+func (q *Query) evalSubstring(e *substringExpr, rec *object) (value, error) {
+	x, err := q.eval(e.x, rec)
+	if err != nil || x == nil {
+		return nil, err
+	}
+	v, err := q.eval(e.from, rec)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	from, ok := toInt(v)
+	if !ok {
+		return nil, fmt.Errorf("SUBSTRING: %v is not an integer", v)
+	}
+	runes := []rune(toString(x))
+	// Positions are 1-based and may start before the string.
+	end := int64(len(runes)) + 1
+	if e.length != nil {
+		v, err = q.eval(e.length, rec)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		length, ok := toInt(v)
+		if !ok || length < 0 {
+			return nil, fmt.Errorf("SUBSTRING: invalid length %v", v)
+		}
+		if from+length < end {
+			end = from + length
+		}
+	}
+	if from < 1 {
+		from = 1
+	}
+	if from >= end {
+		return "", nil
+	}
+	return string(runes[from-1 : end-1]), nil
+}
+
+func (q *Query) evalCall(e *callExpr, rec *object) (value, error) {
+	args := make([]value, len(e.args))
+	for i, arg := range e.args {
+		v, err := q.eval(arg, rec)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	switch e.name {
+	case "coalesce":
+		for _, v := range args {
+			if v != nil {
+				return v, nil
+			}
+		}
+		return nil, nil
+	case "nullif":
+		if c, ok := compare(args[0], args[1]); ok && c == 0 {
+			return nil, nil
+		}
+		return args[0], nil
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	s := toString(args[0])
+	switch e.name {
+	case "lower":
+		return strings.ToLower(s), nil
+	case "upper":
+		return strings.ToUpper(s), nil
+	case "char_length", "character_length":
+		return int64(len([]rune(s))), nil
+	}
+	return nil, fmt.Errorf("unsupported function %s", strings.ToUpper(e.name))
+}
+
+// toNumber converts a value to int64 or float64, strings are parsed.
+func toNumber(v value) (value, bool) {
+	switch v := v.(type) {
+	case int64, float64:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func toFloat(v value) (float64, bool) {
+	n, ok := toNumber(v)
+	switch n := n.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, ok
+}
+
+func toInt(v value) (int64, bool) {
+	n, ok := toNumber(v)
+	switch n := n.(type) {
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, ok
+}
+
+// toString formats a value the way it is written to CSV output.
+func toString(v value) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	var sb strings.Builder
+	writeJSON(&sb, v)
+	return sb.String()
+}
+
+func arithmetic(op string, l, r value) (value, error) {
+	a, ok := toNumber(l)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a number", l)
+	}
+	b, ok := toNumber(r)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a number", r)
+	}
+	ai, aInt := a.(int64)
+	bi, bInt := b.(int64)
+	if aInt && bInt {
+		switch op {
+		case "+":
+			return ai + bi, nil
+		case "-":
+			return ai - bi, nil
+		case "*":
+			return ai * bi, nil
+		case "/", "%":
+			if bi == 0 {
+				return nil, errors.New("division by zero")
+			}
+			if op == "%" {
+				return ai % bi, nil
+			}
+			if ai%bi == 0 {
+				return ai / bi, nil
+			}
+		}
+	}
+	af, _ := toFloat(a)
+	bf, _ := toFloat(b)
+	switch op {
+	case "+":
+		return af + bf, nil
+	case "-":
+		return af - bf, nil
+	case "*":
+		return af * bf, nil
+	}
+	if bf == 0 {
+		return nil, errors.New("division by zero")
+	}
+	if op == "%" {
+		return math.Mod(af, bf), nil
+	}
+	return af / bf, nil
+}
+
+// compare orders two values, ok is false when they are not comparable.
+// Strings compared with numbers are parsed as numbers.
+func compare(l, r value) (c int, ok bool) {
+	if l == nil || r == nil {
+		return 0, false
+	}
+	_, lnum := l.(int64)
+	if _, isFloat := l.(float64); isFloat {
+		lnum = true
+	}
+	_, rnum := r.(int64)
+	if _, isFloat := r.(float64); isFloat {
+		rnum = true
+	}
+	if lnum || rnum {
+		a, aok := toNumber(l)
+		b, bok := toNumber(r)
+		if !aok || !bok {
+			return 0, false
+		}
+		ai, aInt := a.(int64)
+		bi, bInt := b.(int64)
+		if aInt && bInt {
+			return compareOrdered(ai < bi, ai > bi), true
+		}
+		af, _ := toFloat(a)
+		bf, _ := toFloat(b)
+		return compareOrdered(af < bf, af > bf), true
+	}
+	switch a := l.(type) {
+	case string:
+		if b, isString := r.(string); isString {
+			return strings.Compare(a, b), true
+		}
+		if b, isBool := r.(bool); isBool {
+			if ab, err := strconv.ParseBool(a); err == nil {
+				return compareOrdered(!ab && b, ab && !b), true
+			}
+		}
+	case bool:
+		if b, isBool := r.(bool); isBool {
+			return compareOrdered(!a && b, a && !b), true
+		}
+		if _, isString := r.(string); isString {
+			c, ok := compare(r, l)
+			return -c, ok
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func cast(v value, typ string) (value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch typ {
+	case "string":
+		return toString(v), nil
+	case "int":
+		if b, ok := v.(bool); ok {
+			if b {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+		if i, ok := toInt(v); ok {
+			return i, nil
+		}
+	case "float":
+		if f, ok := toFloat(v); ok {
+			return f, nil
+		}
+	case "bool":
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot cast %q to %s", toString(v), strings.ToUpper(typ))
+}
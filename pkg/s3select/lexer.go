@@ -0,0 +1,144 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a lexical token of a query.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// token is a lexical token of a query, keywords are identifiers.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// is returns true if the token is the keyword or operator s.
+func (t token) is(s string) bool {
+	switch t.kind {
+	case tokenIdent:
+		return strings.EqualFold(t.text, s)
+	case tokenOperator:
+		return t.text == s
+	}
+	return false
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return "'" + t.text + "'"
+	case tokenQuotedIdent:
+		return `"` + t.text + `"`
+	}
+	return "`" + t.text + "`"
+}
+
+// Operators of the query language, longest first.
+var operators = []string{"<=", ">=", "<>", "!=", "||", "=", "<", ">", "+", "-", "*", "/", "%", "(", ")", ",", ".", "[", "]", ";"}
+
+// tokenize splits a query into tokens, the last token is always
+// tokenEOF.
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			// Quotes are escaped by doubling them.
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						sb.WriteRune(r)
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+			}
+			kind := tokenString
+			if r == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: sb.String(), pos: i})
+			i = j + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			if j < len(runes) && (runes[j] == 'e' || runes[j] == 'E') {
+				k := j + 1
+				if k < len(runes) && (runes[k] == '+' || runes[k] == '-') {
+					k++
+				}
+				if k < len(runes) && unicode.IsDigit(runes[k]) {
+					for j = k; j < len(runes) && unicode.IsDigit(runes[j]); j++ {
+					}
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[i:j]), pos: i})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[i:j]), pos: i})
+			i = j
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
@@ -0,0 +1,652 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// expr is a node of a parsed expression.
+type expr interface{}
+
+type literalExpr struct{ v value }
+
+// pathElem is one step of a column path, either a (possibly quoted)
+// name or an array index.
+type pathElem struct {
+	name    string
+	quoted  bool
+	index   int
+	isIndex bool
+}
+
+type columnExpr struct{ path []pathElem }
+
+type unaryExpr struct {
+	op string
+	x  expr
+}
+
+type binaryExpr struct {
+	op   string
+	l, r expr
+}
+
+type likeExpr struct {
+	x, pattern, escape expr
+	not                bool
+}
+
+type betweenExpr struct {
+	x, lo, hi expr
+	not       bool
+}
+
+type inExpr struct {
+	x    expr
+	list []expr
+	not  bool
+}
+
+type isNullExpr struct {
+	x   expr
+	not bool
+}
+
+type castExpr struct {
+	x   expr
+	typ string
+}
+
+type trimExpr struct {
+	x, chars expr
+	where    string
+}
+
+type substringExpr struct{ x, from, length expr }
+
+// callExpr is a function call, aggregate calls carry their
+// accumulated state.
+type callExpr struct {
+	name string
+	args []expr
+	star bool
+	agg  *aggregate
+}
+
+// projection is a selected expression with its output name.
+type projection struct {
+	e     expr
+	alias string
+}
+
+// Query is a parsed SQL select statement.
+type Query struct {
+	star        bool
+	projections []projection
+	tableAlias  string
+	where       expr
+	limit       int64
+	aggregates  []*callExpr
+}
+
+// Keywords which can never be used as unquoted column names or aliases.
+var reservedWords = map[string]bool{
+	"select": true, "from": true, "where": true, "limit": true, "as": true,
+	"and": true, "or": true, "not": true, "like": true, "escape": true,
+	"between": true, "in": true, "is": true, "null": true, "true": true,
+	"false": true, "cast": true,
+}
+
+var aggregateFuncs = map[string]bool{"count": true, "sum": true, "avg": true, "min": true, "max": true}
+
+var castTypes = map[string]string{
+	"int": "int", "integer": "int",
+	"float": "float", "decimal": "float", "numeric": "float", "double": "float", "real": "float",
+	"string": "string", "varchar": "string", "char": "string",
+	"bool": "bool", "boolean": "bool",
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	query  *Query
+	// inAggregate is set while parsing the arguments of an aggregate.
+	inAggregate bool
+	// columnOutsideAggregate is set when a column is referenced outside
+	// any aggregate in the projection list.
+	columnOutsideAggregate bool
+}
+
+// Parse parses an SQL select statement of the form
+//
+//	SELECT * | expr [[AS] alias], ... FROM S3Object [[AS] alias] [WHERE expr] [LIMIT n]
+func Parse(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, query: &Query{limit: -1}}
+	if err = p.parseSelect(); err != nil {
+		return nil, err
+	}
+	return p.query, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// accept consumes the next token if it is the keyword or operator s.
+func (p *parser) accept(s string) bool {
+	if p.peek().is(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.accept(s) {
+		return p.unexpected("`" + strings.ToUpper(s) + "`")
+	}
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	t := p.peek()
+	return fmt.Errorf("expected %s at position %d, found %s", want, t.pos+1, t)
+}
+
+// isName returns true if t can be used as a column name or alias.
+func isName(t token) bool {
+	return t.kind == tokenQuotedIdent || (t.kind == tokenIdent && !reservedWords[strings.ToLower(t.text)])
+}
+
+func (p *parser) parseSelect() error {
+	if err := p.expect("select"); err != nil {
+		return err
+	}
+	if p.accept("*") {
+		p.query.star = true
+	} else {
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return err
+			}
+			proj := projection{e: e}
+			if p.accept("as") {
+				if !isName(p.peek()) {
+					return p.unexpected("alias")
+				}
+				proj.alias = p.next().text
+			} else if isName(p.peek()) {
+				proj.alias = p.next().text
+			}
+			p.query.projections = append(p.query.projections, proj)
+			if !p.accept(",") {
+				break
+			}
+		}
+		if len(p.query.aggregates) > 0 && p.columnOutsideAggregate {
+			return errors.New("cannot mix aggregate and non-aggregate columns in the projection")
+		}
+	}
+
+	if err := p.expect("from"); err != nil {
+		return err
+	}
+	if t := p.next(); t.kind != tokenIdent || !strings.EqualFold(t.text, "s3object") {
+		p.pos--
+		return p.unexpected("`S3Object`")
+	}
+	if p.accept("[") {
+		if err := p.expect("*"); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	}
+	if p.accept("as") {
+		if !isName(p.peek()) {
+			return p.unexpected("alias")
+		}
+		p.query.tableAlias = p.next().text
+	} else if isName(p.peek()) {
+		p.query.tableAlias = p.next().text
+	}
+
+	if p.accept("where") {
+		aggregates := len(p.query.aggregates)
+		e, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		if len(p.query.aggregates) != aggregates {
+			return errors.New("aggregate functions are not allowed in the WHERE clause")
+		}
+		p.query.where = e
+	}
+	if p.accept("limit") {
+		t := p.next()
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if t.kind != tokenNumber || err != nil || n < 0 {
+			p.pos--
+			return p.unexpected("a non-negative integer")
+		}
+		p.query.limit = n
+	}
+	p.accept(";")
+	if p.peek().kind != tokenEOF {
+		return p.unexpected("end of query")
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "or", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "and", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept("not") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"=", "!=", "<>", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			r, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			if op == "<>" {
+				op = "!="
+			}
+			return &binaryExpr{op: op, l: l, r: r}, nil
+		}
+	}
+
+	if p.accept("is") {
+		not := p.accept("not")
+		if err = p.expect("null"); err != nil {
+			return nil, err
+		}
+		return &isNullExpr{x: l, not: not}, nil
+	}
+
+	not := p.accept("not")
+	switch {
+	case p.accept("like"):
+		e := &likeExpr{x: l, not: not}
+		if e.pattern, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+		if p.accept("escape") {
+			if e.escape, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+		}
+		return e, nil
+	case p.accept("between"):
+		e := &betweenExpr{x: l, not: not}
+		if e.lo, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+		if err = p.expect("and"); err != nil {
+			return nil, err
+		}
+		if e.hi, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case p.accept("in"):
+		e := &inExpr{x: l, not: not}
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		for {
+			item, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			e.list = append(e.list, item)
+			if !p.accept(",") {
+				break
+			}
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if not {
+		return nil, p.unexpected("`LIKE`, `BETWEEN` or `IN`")
+	}
+	return l, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	l, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if !op.is("+") && !op.is("-") && !op.is("||") {
+			return l, nil
+		}
+		p.next()
+		r, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op.text, l: l, r: r}
+	}
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if !op.is("*") && !op.is("/") && !op.is("%") {
+			return l, nil
+		}
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op.text, l: l, r: r}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.accept("-") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "-", x: x}, nil
+	}
+	p.accept("+")
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenNumber:
+		p.next()
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literalExpr{v: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d", t, t.pos+1)
+		}
+		return &literalExpr{v: f}, nil
+	case t.kind == tokenString:
+		p.next()
+		return &literalExpr{v: t.text}, nil
+	case t.is("null"):
+		p.next()
+		return &literalExpr{v: nil}, nil
+	case t.is("true"), t.is("false"):
+		p.next()
+		return &literalExpr{v: t.is("true")}, nil
+	case t.is("("):
+		p.next()
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case t.is("cast"):
+		return p.parseCast()
+	case t.kind == tokenIdent && p.tokens[p.pos+1].is("("):
+		return p.parseCall()
+	case isName(t):
+		return p.parseColumn()
+	}
+	return nil, p.unexpected("an expression")
+}
+
+func (p *parser) parseColumn() (expr, error) {
+	t := p.next()
+	col := &columnExpr{path: []pathElem{{name: t.text, quoted: t.kind == tokenQuotedIdent}}}
+	for {
+		if p.accept(".") {
+			t = p.next()
+			if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+				p.pos--
+				return nil, p.unexpected("a field name")
+			}
+			col.path = append(col.path, pathElem{name: t.text, quoted: t.kind == tokenQuotedIdent})
+		} else if p.accept("[") {
+			t = p.next()
+			n, err := strconv.Atoi(t.text)
+			if t.kind != tokenNumber || err != nil || n < 0 {
+				p.pos--
+				return nil, p.unexpected("an array index")
+			}
+			if err = p.expect("]"); err != nil {
+				return nil, err
+			}
+			col.path = append(col.path, pathElem{index: n, isIndex: true})
+		} else {
+			break
+		}
+	}
+	if !p.inAggregate {
+		p.columnOutsideAggregate = true
+	}
+	return col, nil
+}
+
+func (p *parser) parseCast() (expr, error) {
+	p.next()
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	x, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect("as"); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	typ, ok := castTypes[strings.ToLower(t.text)]
+	if t.kind != tokenIdent || !ok {
+		p.pos--
+		return nil, p.unexpected("a type name")
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &castExpr{x: x, typ: typ}, nil
+}
+
+func (p *parser) parseCall() (expr, error) {
+	name := strings.ToLower(p.next().text)
+	p.next() // (
+	switch name {
+	case "trim":
+		return p.parseTrim()
+	case "substring":
+		return p.parseSubstring()
+	}
+
+	call := &callExpr{name: name}
+	if aggregateFuncs[name] {
+		if p.inAggregate {
+			return nil, errors.New("aggregate functions cannot be nested")
+		}
+		p.inAggregate = true
+		defer func() { p.inAggregate = false }()
+		call.agg = &aggregate{}
+		p.query.aggregates = append(p.query.aggregates, call)
+		if name == "count" && p.accept("*") {
+			call.star = true
+			return call, p.expect(")")
+		}
+	} else if !scalarFuncs[name] {
+		return nil, fmt.Errorf("unsupported function %s", strings.ToUpper(name))
+	}
+
+	if !p.peek().is(")") {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if err := checkArity(call); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// TRIM([[LEADING|TRAILING|BOTH] [chars] FROM] s)
+func (p *parser) parseTrim() (expr, error) {
+	e := &trimExpr{where: "both"}
+	for _, where := range []string{"leading", "trailing", "both"} {
+		if p.accept(where) {
+			e.where = where
+			break
+		}
+	}
+	if !p.accept("from") {
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("from") {
+			e.chars = x
+		} else {
+			e.x = x
+		}
+	}
+	if e.x == nil {
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		e.x = x
+	}
+	return e, p.expect(")")
+}
+
+// SUBSTRING(s FROM n [FOR m]) or SUBSTRING(s, n [, m])
+func (p *parser) parseSubstring() (expr, error) {
+	e := &substringExpr{}
+	var err error
+	if e.x, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if p.accept("from") {
+		if e.from, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if p.accept("for") {
+			if e.length, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+		if e.from, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if p.accept(",") {
+			if e.length, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return e, p.expect(")")
+}
@@ -0,0 +1,271 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+// recordReader returns the records of an input, io.EOF ends the input.
+type recordReader interface {
+	Read() (*object, error)
+}
+
+// newRecordReader returns a reader for the input serialization of r,
+// processed counts the uncompressed bytes read.
+func newRecordReader(r io.Reader, input minio.SelectObjectInputSerialization, processed *int64) (recordReader, error) {
+	switch strings.ToUpper(string(input.CompressionType)) {
+	case "", string(minio.SelectCompressionNONE):
+	case minio.SelectCompressionGZIP:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = gr
+	case minio.SelectCompressionBZIP:
+		r = bzip2.NewReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported compression type %s", input.CompressionType)
+	}
+	r = countingReader{r, processed}
+
+	switch {
+	case input.CSV != nil:
+		return newCSVReader(r, input.CSV)
+	case input.JSON != nil:
+		return newJSONReader(r, input.JSON), nil
+	case input.Parquet != nil:
+		return nil, errors.New("parquet input is not supported")
+	}
+	return nil, errors.New("input serialization must be one of CSV, JSON or Parquet")
+}
+
+// singleRune returns the only rune of s, or def if s is empty.
+func singleRune(s string, def rune, name string) (rune, error) {
+	if s == "" {
+		return def, nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) {
+		return 0, fmt.Errorf("%s must be a single character", name)
+	}
+	return r, nil
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+func newCSVReader(r io.Reader, opts *minio.CSVInputOptions) (recordReader, error) {
+	if opts.QuoteCharacter != "" && opts.QuoteCharacter != `"` {
+		return nil, errors.New("only `\"` is supported as CSV quote character")
+	}
+	if opts.QuoteEscapeCharacter != "" && opts.QuoteEscapeCharacter != `"` {
+		return nil, errors.New("only `\"` is supported as CSV quote escape character")
+	}
+	if opts.RecordDelimiter != "" && opts.RecordDelimiter != "\n" && opts.RecordDelimiter != "\r\n" {
+		r = newDelimitedReader(r, opts.RecordDelimiter)
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	var err error
+	if cr.Comma, err = singleRune(opts.FieldDelimiter, ',', "CSV field delimiter"); err != nil {
+		return nil, err
+	}
+	if cr.Comment, err = singleRune(opts.Comments, 0, "CSV comment character"); err != nil {
+		return nil, err
+	}
+
+	c := &csvReader{r: cr}
+	switch strings.ToUpper(string(opts.FileHeaderInfo)) {
+	case minio.CSVFileHeaderInfoUse, minio.CSVFileHeaderInfoIgnore:
+		header, err := cr.Read()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if strings.EqualFold(string(opts.FileHeaderInfo), minio.CSVFileHeaderInfoUse) {
+			c.header = header
+		}
+	}
+	return c, nil
+}
+
+func (c *csvReader) Read() (*object, error) {
+	fields, err := c.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	rec := &object{}
+	for i, f := range fields {
+		name := "_" + strconv.Itoa(i+1)
+		if i < len(c.header) {
+			name = c.header[i]
+		}
+		rec.add(name, f)
+	}
+	return rec, nil
+}
+
+// delimitedReader rewrites a custom record delimiter into newlines.
+type delimitedReader struct {
+	s   *bufio.Scanner
+	buf []byte
+}
+
+func newDelimitedReader(r io.Reader, delim string) io.Reader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, []byte(delim)); i >= 0 {
+			return i + len(delim), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return &delimitedReader{s: s}
+}
+
+func (d *delimitedReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if !d.s.Scan() {
+			if err := d.s.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		d.buf = append(append(d.buf, d.s.Bytes()...), '\n')
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+// jsonReader reads a stream of JSON values, the elements of a top
+// level array are returned as separate records.
+type jsonReader struct {
+	d       *json.Decoder
+	inArray bool
+}
+
+func newJSONReader(r io.Reader, opts *minio.JSONInputOptions) recordReader {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return &jsonReader{d: d}
+}
+
+func (j *jsonReader) Read() (*object, error) {
+	for {
+		if j.inArray && !j.d.More() {
+			// Consume the closing bracket.
+			if _, err := j.d.Token(); err != nil {
+				return nil, err
+			}
+			j.inArray = false
+			continue
+		}
+		t, err := j.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		if t == json.Delim('[') && !j.inArray {
+			j.inArray = true
+			continue
+		}
+		v, err := j.decodeValue(t)
+		if err != nil {
+			return nil, err
+		}
+		if o, ok := v.(*object); ok {
+			return o, nil
+		}
+		// Scalars are exposed as a record with a single column.
+		rec := &object{}
+		rec.add("_1", v)
+		return rec, nil
+	}
+}
+
+// decodeValue decodes the value starting with token t, keeping the
+// order of object keys.
+func (j *jsonReader) decodeValue(t json.Token) (value, error) {
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			o := &object{}
+			for j.d.More() {
+				kt, err := j.d.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := kt.(string)
+				vt, err := j.d.Token()
+				if err != nil {
+					return nil, err
+				}
+				v, err := j.decodeValue(vt)
+				if err != nil {
+					return nil, err
+				}
+				o.add(key, v)
+			}
+			_, err := j.d.Token()
+			return o, err
+		case '[':
+			a := []value{}
+			for j.d.More() {
+				vt, err := j.d.Token()
+				if err != nil {
+					return nil, err
+				}
+				v, err := j.decodeValue(vt)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, v)
+			}
+			_, err := j.d.Token()
+			return a, err
+		}
+		return nil, fmt.Errorf("unexpected %v in JSON input", t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	case string, bool, nil:
+		return t, nil
+	}
+	return nil, fmt.Errorf("unexpected %v in JSON input", t)
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s3select evaluates the SQL subset of the S3 Select API
+// locally, so that queries can run against files which are not stored
+// on an S3 server.
+package s3select
+
+import (
+	"io"
+	"strconv"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Stats counts the bytes read and written by a query, like the stats
+// messages of the S3 Select API.
+type Stats struct {
+	BytesScanned   int64
+	BytesProcessed int64
+	BytesReturned  int64
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// Run evaluates the query against the records read from r, serialized
+// according to input, and writes the results to w according to output.
+func (q *Query) Run(r io.Reader, input minio.SelectObjectInputSerialization, output minio.SelectObjectOutputSerialization, w io.Writer) (stats Stats, err error) {
+	err = q.run(countingReader{r, &stats.BytesScanned}, input, output, countingWriter{w, &stats.BytesReturned}, &stats.BytesProcessed)
+	return stats, err
+}
+
+func (q *Query) run(r io.Reader, input minio.SelectObjectInputSerialization, output minio.SelectObjectOutputSerialization, w io.Writer, processed *int64) error {
+	records, err := newRecordReader(r, input, processed)
+	if err != nil {
+		return err
+	}
+	writer, err := newRecordWriter(w, output)
+	if err != nil {
+		return err
+	}
+	for _, call := range q.aggregates {
+		call.agg = &aggregate{}
+	}
+
+	var written int64
+	for q.limit < 0 || written < q.limit || len(q.aggregates) > 0 {
+		rec, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if q.where != nil {
+			v, err := q.eval(q.where, rec)
+			if err != nil {
+				return err
+			}
+			if match, _ := v.(bool); !match {
+				continue
+			}
+		}
+		if len(q.aggregates) > 0 {
+			for _, call := range q.aggregates {
+				if err = call.agg.update(call, rec, q); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		out, err := q.project(rec)
+		if err != nil {
+			return err
+		}
+		if err = writer.Write(out); err != nil {
+			return err
+		}
+		written++
+	}
+
+	// Aggregate queries produce a single row.
+	if len(q.aggregates) > 0 && q.limit != 0 {
+		out, err := q.project(nil)
+		if err != nil {
+			return err
+		}
+		if err = writer.Write(out); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// project evaluates the selected expressions for a record.
+func (q *Query) project(rec *object) (*object, error) {
+	if q.star {
+		return rec, nil
+	}
+	out := &object{}
+	for i, p := range q.projections {
+		v, err := q.eval(p.e, rec)
+		if err != nil {
+			return nil, err
+		}
+		name := p.alias
+		if name == "" {
+			name = "_" + strconv.Itoa(i+1)
+			if col, ok := p.e.(*columnExpr); ok && !col.path[len(col.path)-1].isIndex {
+				name = col.path[len(col.path)-1].name
+			}
+		}
+		out.add(name, v)
+	}
+	return out, nil
+}
@@ -0,0 +1,210 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+const testCSV = `name,age,city
+alice,31,Paris
+bob,25,"New York, NY"
+carol,,Berlin
+dave,42,paris
+`
+
+const testJSON = `{"name":"alice","age":31,"address":{"city":"Paris"},"tags":["a","b"]}
+{"name":"bob","age":25,"address":{"city":"New York"},"tags":[]}
+{"name":"carol","age":null,"address":{"city":"Berlin"}}
+`
+
+func csvInput() minio.SelectObjectInputSerialization {
+	return minio.SelectObjectInputSerialization{
+		CSV: &minio.CSVInputOptions{FileHeaderInfo: minio.CSVFileHeaderInfoUse, RecordDelimiter: "\n"},
+	}
+}
+
+func jsonInput() minio.SelectObjectInputSerialization {
+	return minio.SelectObjectInputSerialization{
+		JSON: &minio.JSONInputOptions{Type: minio.JSONLinesType},
+	}
+}
+
+func csvOutput() minio.SelectObjectOutputSerialization {
+	return minio.SelectObjectOutputSerialization{
+		CSV: &minio.CSVOutputOptions{RecordDelimiter: "\n", FieldDelimiter: ","},
+	}
+}
+
+func jsonOutput() minio.SelectObjectOutputSerialization {
+	return minio.SelectObjectOutputSerialization{
+		JSON: &minio.JSONOutputOptions{RecordDelimiter: "\n"},
+	}
+}
+
+func runQuery(t *testing.T, query, data string, input minio.SelectObjectInputSerialization, output minio.SelectObjectOutputSerialization) (string, error) {
+	t.Helper()
+	q, err := Parse(query)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	_, err = q.Run(strings.NewReader(data), input, output, &buf)
+	return buf.String(), err
+}
+
+func TestSelectCSV(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM S3Object", "alice,31,Paris\nbob,25,\"New York, NY\"\ncarol,,Berlin\ndave,42,paris\n"},
+		{"select name from s3object where age > 30", "alice\ndave\n"},
+		{"SELECT s.name, s.age + 1 FROM S3Object s WHERE s.city = 'Paris'", "alice,32\n"},
+		{"SELECT name FROM S3Object LIMIT 2", "alice\nbob\n"},
+		{"SELECT _1, _3 FROM S3Object WHERE LOWER(city) = 'paris'", "alice,Paris\ndave,paris\n"},
+		{"SELECT name FROM S3Object WHERE age IS NULL OR age = ''", "carol\n"},
+		{"SELECT name FROM S3Object WHERE name LIKE '_a%'", "carol\ndave\n"},
+		{"SELECT name FROM S3Object WHERE age BETWEEN 25 AND 31", "alice\nbob\n"},
+		{"SELECT name FROM S3Object WHERE name NOT IN ('alice', 'bob')", "carol\ndave\n"},
+		{"SELECT UPPER(name) || '-' || city FROM S3Object WHERE name = 'dave'", "DAVE-paris\n"},
+		{"SELECT COUNT(*), SUM(age), MIN(age), MAX(age) FROM S3Object WHERE age <> ''", "3,98,25,42\n"},
+		{"SELECT AVG(CAST(age AS FLOAT)) FROM S3Object WHERE name = 'alice' OR name = 'bob'", "28\n"},
+		{"SELECT CAST(age AS INT) * 2 FROM S3Object WHERE name = 'dave'", "84\n"},
+		{"SELECT COUNT(age) FROM S3Object WHERE city = 'nowhere'", "0\n"},
+		{"SELECT SUBSTRING(name FROM 2 FOR 3), TRIM(LEADING 'a' FROM name) FROM S3Object LIMIT 1", "lic,lice\n"},
+	}
+	for i, testCase := range testCases {
+		out, err := runQuery(t, testCase.query, testCSV, csvInput(), csvOutput())
+		if err != nil {
+			t.Fatalf("Test %d: %s: unexpected error %v", i+1, testCase.query, err)
+		}
+		if out != testCase.expected {
+			t.Fatalf("Test %d: %s: expected %q, got %q", i+1, testCase.query, testCase.expected, out)
+		}
+	}
+}
+
+func TestSelectMinMax(t *testing.T) {
+	// Numbers of different widths are not compared as strings.
+	data := "size,name\n100,a\n5,b\n30.5,c\n"
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT MIN(size), MAX(size) FROM S3Object", "5,100\n"},
+		{"SELECT MIN(name), MAX(name) FROM S3Object", "a,c\n"},
+	}
+	for i, testCase := range testCases {
+		out, err := runQuery(t, testCase.query, data, csvInput(), csvOutput())
+		if err != nil {
+			t.Fatalf("Test %d: %s: unexpected error %v", i+1, testCase.query, err)
+		}
+		if out != testCase.expected {
+			t.Fatalf("Test %d: %s: expected %q, got %q", i+1, testCase.query, testCase.expected, out)
+		}
+	}
+}
+
+func TestSelectJSON(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM S3Object[*] LIMIT 1", `{"name":"alice","age":31,"address":{"city":"Paris"},"tags":["a","b"]}` + "\n"},
+		{"SELECT s.name, s.address.city AS city FROM S3Object[*] s WHERE s.age < 30", `{"name":"bob","city":"New York"}` + "\n"},
+		{"SELECT name, tags[1] FROM S3Object WHERE tags[0] = 'a'", `{"name":"alice","_2":"b"}` + "\n"},
+		{"SELECT COUNT(*) AS n, AVG(age) AS avg FROM S3Object", `{"n":3,"avg":28}` + "\n"},
+		{`SELECT "name" FROM S3Object WHERE age IS NULL`, `{"name":"carol"}` + "\n"},
+	}
+	for i, testCase := range testCases {
+		out, err := runQuery(t, testCase.query, testJSON, jsonInput(), jsonOutput())
+		if err != nil {
+			t.Fatalf("Test %d: %s: unexpected error %v", i+1, testCase.query, err)
+		}
+		if out != testCase.expected {
+			t.Fatalf("Test %d: %s: expected %q, got %q", i+1, testCase.query, testCase.expected, out)
+		}
+	}
+
+	// JSON documents holding an array yield one record per element.
+	input := minio.SelectObjectInputSerialization{JSON: &minio.JSONInputOptions{Type: minio.JSONDocumentType}}
+	out, err := runQuery(t, "SELECT a FROM S3Object", `[{"a":1},{"a":2}]`, input, csvOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "1\n2\n" {
+		t.Fatalf("expected %q, got %q", "1\n2\n", out)
+	}
+}
+
+func TestSelectSerialization(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte("a;b|1;x y|2;z\"z|"))
+	gw.Close()
+
+	input := minio.SelectObjectInputSerialization{
+		CompressionType: minio.SelectCompressionGZIP,
+		CSV: &minio.CSVInputOptions{
+			FileHeaderInfo:  minio.CSVFileHeaderInfoUse,
+			RecordDelimiter: "|",
+			FieldDelimiter:  ";",
+		},
+	}
+	output := minio.SelectObjectOutputSerialization{
+		CSV: &minio.CSVOutputOptions{
+			RecordDelimiter: "\r\n",
+			FieldDelimiter:  "\t",
+			QuoteFields:     minio.CSVQuoteFieldsAlways,
+		},
+	}
+	out, err := runQuery(t, "SELECT b, a FROM S3Object", gz.String(), input, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "\"x y\"\t\"1\"\r\n\"z\"\"z\"\t\"2\"\r\n"
+	if out != expected {
+		t.Fatalf("expected %q, got %q", expected, out)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []string{
+		"",
+		"SELECT",
+		"SELECT * FROM table",
+		"SELECT name, COUNT(*) FROM S3Object",
+		"SELECT * FROM S3Object WHERE COUNT(*) > 1",
+		"SELECT * FROM S3Object LIMIT -1",
+		"SELECT * FROM S3Object WHERE name = 'alice",
+		"SELECT SUM(MAX(a)) FROM S3Object",
+		"SELECT FOO(a) FROM S3Object",
+		"SELECT * FROM S3Object WHERE a NOT 1",
+		"SELECT * FROM S3Object extra tokens",
+	}
+	for i, query := range testCases {
+		if _, err := Parse(query); err == nil {
+			t.Fatalf("Test %d: %q: expected an error", i+1, query)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+// recordWriter serializes output records.
+type recordWriter interface {
+	Write(rec *object) error
+	Flush() error
+}
+
+func newRecordWriter(w io.Writer, output minio.SelectObjectOutputSerialization) (recordWriter, error) {
+	bw := bufio.NewWriter(w)
+	switch {
+	case output.CSV != nil:
+		return newCSVWriter(bw, output.CSV)
+	case output.JSON != nil:
+		delim := output.JSON.RecordDelimiter
+		if delim == "" {
+			delim = "\n"
+		}
+		return &jsonWriter{w: bw, delim: delim}, nil
+	}
+	return nil, errors.New("output serialization must be one of CSV or JSON")
+}
+
+type csvWriter struct {
+	w            *bufio.Writer
+	fieldDelim   string
+	recordDelim  string
+	quote        string
+	quoteEscape  string
+	alwaysQuoted bool
+}
+
+func newCSVWriter(w *bufio.Writer, opts *minio.CSVOutputOptions) (recordWriter, error) {
+	c := &csvWriter{
+		w:           w,
+		fieldDelim:  opts.FieldDelimiter,
+		recordDelim: opts.RecordDelimiter,
+		quote:       opts.QuoteCharacter,
+		quoteEscape: opts.QuoteEscapeCharacter,
+	}
+	if c.fieldDelim == "" {
+		c.fieldDelim = ","
+	}
+	if c.recordDelim == "" {
+		c.recordDelim = "\n"
+	}
+	if c.quote == "" {
+		c.quote = `"`
+	}
+	if c.quoteEscape == "" {
+		c.quoteEscape = c.quote
+	}
+	switch strings.ToLower(string(opts.QuoteFields)) {
+	case "", strings.ToLower(minio.CSVQuoteFieldsAsNeeded):
+	case strings.ToLower(string(minio.CSVQuoteFieldsAlways)):
+		c.alwaysQuoted = true
+	default:
+		return nil, errors.New("CSV quote fields must be one of Always or AsNeeded")
+	}
+	return c, nil
+}
+
+func (c *csvWriter) Write(rec *object) error {
+	for i, v := range rec.values {
+		if i > 0 {
+			if _, err := c.w.WriteString(c.fieldDelim); err != nil {
+				return err
+			}
+		}
+		s := toString(v)
+		if c.alwaysQuoted || strings.Contains(s, c.fieldDelim) || strings.Contains(s, c.quote) ||
+			strings.Contains(s, c.recordDelim) || strings.ContainsAny(s, "\r\n") {
+			s = c.quote + strings.Replace(s, c.quote, c.quoteEscape+c.quote, -1) + c.quote
+		}
+		if _, err := c.w.WriteString(s); err != nil {
+			return err
+		}
+	}
+	_, err := c.w.WriteString(c.recordDelim)
+	return err
+}
+
+func (c *csvWriter) Flush() error { return c.w.Flush() }
+
+type jsonWriter struct {
+	w     *bufio.Writer
+	delim string
+}
+
+func (j *jsonWriter) Write(rec *object) error {
+	var sb strings.Builder
+	writeJSON(&sb, rec)
+	sb.WriteString(j.delim)
+	_, err := j.w.WriteString(sb.String())
+	return err
+}
+
+func (j *jsonWriter) Flush() error { return j.w.Flush() }
+
+// writeJSON encodes a value as JSON, keeping the order of object keys.
+func writeJSON(sb *strings.Builder, v value) {
+	switch v := v.(type) {
+	case *object:
+		sb.WriteByte('{')
+		for i, k := range v.keys {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeJSON(sb, k)
+			sb.WriteByte(':')
+			writeJSON(sb, v.values[i])
+		}
+		sb.WriteByte('}')
+	case []value:
+		sb.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeJSON(sb, e)
+		}
+		sb.WriteByte(']')
+	case nil:
+		sb.WriteString("null")
+	case string:
+		b, _ := json.Marshal(v)
+		sb.Write(b)
+	default:
+		sb.WriteString(toString(v))
+	}
+}